@@ -2,8 +2,13 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
+	"io"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"gitbub.com/tsongpon/iris/internal/repository"
@@ -12,41 +17,629 @@ import (
 	"github.com/joho/godotenv"
 )
 
-func newEventNotifyServive() (service.EventNotifyService, error) {
-	leaveCalendarID := os.Getenv("LEAVE_CALENDAR_ID")
-	holidayCalendarID := os.Getenv("HOLIDAY_CALENDAR_ID")
-	onCallCalendarID := os.Getenv("ON_CALL_CALENDAR_ID")
-	googleCalendarCredential := os.Getenv("GOOGLE_CREDENTIALS_JSON")
-
-	lineGroupID := os.Getenv("LINE_GROUP_ID")
-	lineChannelToken := os.Getenv("LINE_CHANNEL_TOKEN")
-	lineChannelSecret := os.Getenv("LINE_CHANNEL_SECRET")
-
-	leaveEventRepository := repository.NewGoogleCalendar(googleCalendarCredential, leaveCalendarID)
-	holidayEventRepository := repository.NewGoogleCalendar(googleCalendarCredential, holidayCalendarID)
-	onCallEventRepository := repository.NewGoogleCalendar(googleCalendarCredential, onCallCalendarID)
-	notificationRepo := repository.NewLineNotificationRepository(lineGroupID, lineChannelSecret, lineChannelToken)
+func newEventNotifyServive(run jobRun) (service.EventNotifyService, error) {
+	leaveEventRepository, err := run.eventRepository("LEAVE")
+	if err != nil {
+		return service.EventNotifyService{}, err
+	}
+	holidayEventRepository, err := run.eventRepository("HOLIDAY")
+	if err != nil {
+		return service.EventNotifyService{}, err
+	}
+	onCallEventRepository, err := run.eventRepository("ON_CALL")
+	if err != nil {
+		return service.EventNotifyService{}, err
+	}
+	notificationRepo, err := newNotificationRepository(run)
+	if err != nil {
+		return service.EventNotifyService{}, err
+	}
 	eventNotify := service.NewEventNotifyService(leaveEventRepository, holidayEventRepository, onCallEventRepository, notificationRepo)
 
+	if os.Getenv("CAPACITY_MIN_AVAILABLE") != "" {
+		people, err := repository.LoadPeopleDirectory(os.Getenv("PEOPLE_DIRECTORY"))
+		if err != nil {
+			return service.EventNotifyService{}, err
+		}
+		policy, err := newCapacityPolicy()
+		if err != nil {
+			return service.EventNotifyService{}, err
+		}
+		eventNotify = eventNotify.WithCapacityCheck(people, policy)
+	}
+	if os.Getenv("ON_CALL_COVERAGE_CHECK") == "true" {
+		eventNotify = eventNotify.WithOnCallRequired()
+	}
+	eventNotify = eventNotify.WithNextWorkingDayTemplate(service.NextWorkingDayTemplate{
+		LeaveHeading:  os.Getenv("NEXT_WORKING_DAY_LEAVE_HEADING"),
+		OnCallHeading: os.Getenv("NEXT_WORKING_DAY_ON_CALL_HEADING"),
+	})
+
 	return eventNotify, nil
 }
 
-// Handle call from AWS Lambda
-func HandleRequest(ctx context.Context) error {
-	log.Printf("Running Lambda hendler function")
+// newCapacityPolicy reads the staffing thresholds: CAPACITY_MIN_AVAILABLE is the percentage of a
+// team that must be available, CAPACITY_TEAM_MIN_AVAILABLE overrides it per team ("backend:60,qa:50")
+// and CAPACITY_LOOKAHEAD_DAYS enables the early-warning scan.
+func newCapacityPolicy() (service.CapacityPolicy, error) {
+	policy := service.CapacityPolicy{TeamMinAvailablePercent: map[string]float64{}}
 	var err error
-	service, err := newEventNotifyServive()
+	policy.MinAvailablePercent, err = strconv.ParseFloat(os.Getenv("CAPACITY_MIN_AVAILABLE"), 64)
+	if err != nil {
+		return service.CapacityPolicy{}, fmt.Errorf("invalid CAPACITY_MIN_AVAILABLE: %v", err)
+	}
+	for _, entry := range splitList(os.Getenv("CAPACITY_TEAM_MIN_AVAILABLE")) {
+		team, value, _ := strings.Cut(entry, ":")
+		threshold, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return service.CapacityPolicy{}, fmt.Errorf("invalid CAPACITY_TEAM_MIN_AVAILABLE entry %q: %v", entry, err)
+		}
+		policy.TeamMinAvailablePercent[strings.TrimSpace(team)] = threshold
+	}
+	policy.LookaheadDays, err = strconv.Atoi(getEnvOrDefault("CAPACITY_LOOKAHEAD_DAYS", "0"))
+	if err != nil {
+		return service.CapacityPolicy{}, fmt.Errorf("invalid CAPACITY_LOOKAHEAD_DAYS: %v", err)
+	}
+	return policy, nil
+}
+
+// newOnCallHandoverService creates the service announcing on-call handovers, posted to the same
+// channels as the daily roster.
+func newOnCallHandoverService(run jobRun) (service.OnCallHandoverService, error) {
+	onCallEventRepository, err := run.eventRepository("ON_CALL")
+	if err != nil {
+		return service.OnCallHandoverService{}, err
+	}
+	holidayEventRepository, err := run.eventRepository("HOLIDAY")
+	if err != nil {
+		return service.OnCallHandoverService{}, err
+	}
+	notificationRepo, err := newNotificationRepository(run)
+	if err != nil {
+		return service.OnCallHandoverService{}, err
+	}
+	return service.NewOnCallHandoverService(onCallEventRepository, holidayEventRepository, notificationRepo), nil
+}
+
+// newWeeklyLeaveDigestService creates the service posting the weekly leave digest.
+func newWeeklyLeaveDigestService(run jobRun) (service.WeeklyLeaveDigestService, error) {
+	leaveEventRepository, err := run.eventRepository("LEAVE")
+	if err != nil {
+		return service.WeeklyLeaveDigestService{}, err
+	}
+	holidayEventRepository, err := run.eventRepository("HOLIDAY")
+	if err != nil {
+		return service.WeeklyLeaveDigestService{}, err
+	}
+	notificationRepo, err := newNotificationRepository(run)
+	if err != nil {
+		return service.WeeklyLeaveDigestService{}, err
+	}
+	return service.NewWeeklyLeaveDigestService(leaveEventRepository, holidayEventRepository, notificationRepo), nil
+}
+
+// newLeaveReportService creates the service reporting the previous month's leave. LEAVE_TYPES
+// overrides the leave types as "ลาป่วย:ป่วย|sick,ลาพักร้อน:พักร้อน|vacation", a type name followed
+// by the keywords that mark it in a leave event.
+func newLeaveReportService(run jobRun) (service.LeaveReportService, error) {
+	leaveEventRepository, err := run.eventRepository("LEAVE")
+	if err != nil {
+		return service.LeaveReportService{}, err
+	}
+	holidayEventRepository, err := run.eventRepository("HOLIDAY")
+	if err != nil {
+		return service.LeaveReportService{}, err
+	}
+	people, err := repository.LoadPeopleDirectory(os.Getenv("PEOPLE_DIRECTORY"))
+	if err != nil {
+		return service.LeaveReportService{}, err
+	}
+	notificationRepo, err := newNotificationRepository(run)
+	if err != nil {
+		return service.LeaveReportService{}, err
+	}
+	var leaveTypes []service.LeaveType
+	for _, entry := range splitList(os.Getenv("LEAVE_TYPES")) {
+		name, keywords, _ := strings.Cut(entry, ":")
+		leaveType := service.LeaveType{Name: strings.TrimSpace(name)}
+		for _, keyword := range strings.Split(keywords, "|") {
+			if keyword = strings.TrimSpace(keyword); keyword != "" {
+				leaveType.Keywords = append(leaveType.Keywords, keyword)
+			}
+		}
+		if len(leaveType.Keywords) == 0 {
+			return service.LeaveReportService{}, fmt.Errorf("invalid LEAVE_TYPES entry %q, expected name:keyword|keyword", entry)
+		}
+		leaveTypes = append(leaveTypes, leaveType)
+	}
+	leaveReport := service.NewLeaveReportService(leaveEventRepository, holidayEventRepository, people, notificationRepo, leaveTypes)
+	if value := os.Getenv("LEAVE_REPORT_EXPORT"); value != "" {
+		store, err := newLeaveReportStore(value)
+		if err != nil {
+			return service.LeaveReportService{}, err
+		}
+		leaveReport = leaveReport.WithExport(store)
+	}
+	return leaveReport, nil
+}
+
+// newLeaveReportStore creates the store for LEAVE_REPORT_EXPORT: "dir:path" or "s3:bucket/prefix".
+// LEAVE_REPORT_S3_ENDPOINT points the S3 store at MinIO or another compatible store.
+func newLeaveReportStore(value string) (service.LeaveReportStore, error) {
+	backend, location, _ := strings.Cut(value, ":")
+	switch backend {
+	case "dir":
+		return repository.NewDirectoryLeaveReportStore(location), nil
+	case "s3":
+		bucket, prefix, _ := strings.Cut(location, "/")
+		if prefix != "" && !strings.HasSuffix(prefix, "/") {
+			prefix += "/"
+		}
+		store, err := repository.NewS3LeaveReportStore(repository.S3Config{
+			Bucket:   bucket,
+			Prefix:   prefix,
+			Endpoint: os.Getenv("LEAVE_REPORT_S3_ENDPOINT"),
+			Region:   os.Getenv("LEAVE_REPORT_S3_REGION"),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown LEAVE_REPORT_EXPORT backend: %s", backend)
+	}
+}
+
+// notifyOnCallHandover announces the on-call handover when ON_CALL_HANDOVER is enabled.
+func notifyOnCallHandover(run jobRun, asOf time.Time) error {
+	if os.Getenv("ON_CALL_HANDOVER") != "true" {
+		return nil
+	}
+	handover, err := newOnCallHandoverService(run)
+	if err != nil {
+		return err
+	}
+	return handover.Notify(asOf)
+}
+
+// notifyOnCallConflicts warns about people on call while on leave when ON_CALL_CONFLICT_CHECK is
+// enabled, checking today and the next ON_CALL_CONFLICT_LOOKAHEAD_DAYS days. The warning goes to
+// ON_CALL_CONFLICT_CHANNEL, e.g. "email" for the admins, or to the team's channels when it is empty.
+func notifyOnCallConflicts(run jobRun, asOf time.Time) error {
+	if os.Getenv("ON_CALL_CONFLICT_CHECK") != "true" {
+		return nil
+	}
+	lookaheadDays, err := strconv.Atoi(getEnvOrDefault("ON_CALL_CONFLICT_LOOKAHEAD_DAYS", "7"))
+	if err != nil {
+		return fmt.Errorf("invalid ON_CALL_CONFLICT_LOOKAHEAD_DAYS: %v", err)
+	}
+	leaveEventRepository, err := run.eventRepository("LEAVE")
+	if err != nil {
+		return err
+	}
+	onCallEventRepository, err := run.eventRepository("ON_CALL")
+	if err != nil {
+		return err
+	}
+	people, err := repository.LoadPeopleDirectory(os.Getenv("PEOPLE_DIRECTORY"))
+	if err != nil {
+		return err
+	}
+	notificationRepo, err := newAlertNotificationRepository(run, "ON_CALL_CONFLICT_CHANNEL")
+	if err != nil {
+		return err
+	}
+	conflicts := service.NewOnCallConflictService(leaveEventRepository, onCallEventRepository, people, notificationRepo, lookaheadDays)
+	return conflicts.Notify(asOf)
+}
+
+// notifyOnCallCoverageGaps alerts the team lead, through ON_CALL_COVERAGE_CHANNEL or the team's
+// channels when it is empty, to the time ranges nobody is on call for in the next
+// ON_CALL_COVERAGE_LOOKAHEAD_DAYS days when ON_CALL_COVERAGE_CHECK is enabled.
+func notifyOnCallCoverageGaps(run jobRun, asOf time.Time) error {
+	if os.Getenv("ON_CALL_COVERAGE_CHECK") != "true" {
+		return nil
+	}
+	lookaheadDays, err := strconv.Atoi(getEnvOrDefault("ON_CALL_COVERAGE_LOOKAHEAD_DAYS", "14"))
+	if err != nil {
+		return fmt.Errorf("invalid ON_CALL_COVERAGE_LOOKAHEAD_DAYS: %v", err)
+	}
+	onCallEventRepository, err := run.eventRepository("ON_CALL")
+	if err != nil {
+		return err
+	}
+	notificationRepo, err := newAlertNotificationRepository(run, "ON_CALL_COVERAGE_CHANNEL")
 	if err != nil {
-		log.Printf("Error creating event handler: %v", err)
 		return err
 	}
+	return service.NewOnCallCoverageService(onCallEventRepository, notificationRepo, lookaheadDays).Notify(asOf)
+}
+
+// newEventRepository creates the event source for a calendar slot (LEAVE, HOLIDAY or ON_CALL).
+// <SLOT>_CALENDAR_TYPE selects the source, Google Calendar by default, and <SLOT>_CALENDAR_ID
+// identifies the calendar within it.
+func newEventRepository(slot string) (service.EventRepository, error) {
+	calendarType := getEnvOrDefault(slot+"_CALENDAR_TYPE", "google")
+	calendarID := os.Getenv(slot + "_CALENDAR_ID")
+	switch calendarType {
+	case "google":
+		return repository.NewGoogleCalendar(os.Getenv("GOOGLE_CREDENTIALS_JSON"), calendarID), nil
+	case "ics":
+		return repository.NewICSCalendar(calendarID), nil
+	case "caldav":
+		return repository.NewCalDAVCalendar(repository.CalDAVConfig{
+			CalendarURL: calendarID,
+			Username:    os.Getenv("CALDAV_USERNAME"),
+			Password:    os.Getenv("CALDAV_PASSWORD"),
+			BearerToken: os.Getenv("CALDAV_BEARER_TOKEN"),
+		}), nil
+	case "file":
+		// An empty <SLOT>_CALENDAR_ID uses the holiday list embedded at build time.
+		list, err := repository.NewHolidayList(calendarID)
+		if err != nil {
+			return nil, err
+		}
+		return list, nil
+	case "thai":
+		// <SLOT>_CALENDAR_ID optionally points at a holiday list of cabinet-announced special holidays.
+		calendar, err := repository.NewThaiHolidayCalendar(calendarID)
+		if err != nil {
+			return nil, err
+		}
+		return calendar, nil
+	case "outlook":
+		// <SLOT>_CALENDAR_ID is "mailbox" for the default calendar or "mailbox/calendar-id".
+		userID, outlookCalendarID, _ := strings.Cut(calendarID, "/")
+		return repository.NewOutlookCalendar(repository.OutlookConfig{
+			TenantID:     os.Getenv("OUTLOOK_TENANT_ID"),
+			ClientID:     os.Getenv("OUTLOOK_CLIENT_ID"),
+			ClientSecret: os.Getenv("OUTLOOK_CLIENT_SECRET"),
+			UserID:       userID,
+			CalendarID:   outlookCalendarID,
+		}), nil
+	case "rotation":
+		// <SLOT>_CALENDAR_ID is the path of the YAML rotation config.
+		rotation, err := repository.NewOnCallRotation(calendarID)
+		if err != nil {
+			return nil, err
+		}
+		return rotation, nil
+	case "pagerduty":
+		// <SLOT>_CALENDAR_ID is the PagerDuty schedule id.
+		people, err := repository.LoadPeopleDirectory(os.Getenv("PEOPLE_DIRECTORY"))
+		if err != nil {
+			return nil, err
+		}
+		return repository.NewPagerDutyOnCall(repository.PagerDutyConfig{
+			APIToken:   os.Getenv("PAGERDUTY_API_TOKEN"),
+			ScheduleID: calendarID,
+			APIURL:     os.Getenv("PAGERDUTY_API_URL"),
+		}, people), nil
+	case "opsgenie":
+		// <SLOT>_CALENDAR_ID is the Opsgenie schedule id, or "name:Schedule Name".
+		people, err := repository.LoadPeopleDirectory(os.Getenv("PEOPLE_DIRECTORY"))
+		if err != nil {
+			return nil, err
+		}
+		scheduleName, isName := strings.CutPrefix(calendarID, "name:")
+		return repository.NewOpsgenieOnCall(repository.OpsgenieConfig{
+			APIKey:         os.Getenv("OPSGENIE_API_KEY"),
+			Schedule:       scheduleName,
+			ScheduleIsName: isName,
+			APIURL:         os.Getenv("OPSGENIE_API_URL"),
+		}, people), nil
+	default:
+		return nil, fmt.Errorf("unknown %s_CALENDAR_TYPE: %s", slot, calendarType)
+	}
+}
+
+// newNotificationRepository selects the channels the team's roster is posted to, LINE by default.
+// NOTIFICATION_CHANNEL is a comma separated list such as "line,email:best-effort"; with more
+// than one channel the roster is fanned out to all of them concurrently.
+func newNotificationRepository(run jobRun) (service.NotificationRepository, error) {
+	return newNotificationRepositoryFor(run, os.Getenv("NOTIFICATION_CHANNEL"))
+}
+
+// newAlertNotificationRepository creates the notifier for an alert routed through the channel list
+// in the key environment variable, falling back to the team's channels when it is empty.
+func newAlertNotificationRepository(run jobRun, key string) (service.NotificationRepository, error) {
+	channels := os.Getenv(key)
+	if channels == "" {
+		channels = os.Getenv("NOTIFICATION_CHANNEL")
+	}
+	return newNotificationRepositoryFor(run, channels)
+}
+
+// newNotificationRepositoryFor creates the notifier for a channel list in the NOTIFICATION_CHANNEL format.
+func newNotificationRepositoryFor(run jobRun, channelList string) (service.NotificationRepository, error) {
+	entries := splitList(channelList)
+	if len(entries) == 0 {
+		entries = []string{"line"}
+	}
+
+	var channels []service.NotificationChannel
+	for _, entry := range entries {
+		name, policyName, _ := strings.Cut(entry, ":")
+		policy := service.NotificationRequired
+		switch policyName {
+		case "", "required":
+		case "best-effort":
+			policy = service.NotificationBestEffort
+		default:
+			return nil, fmt.Errorf("unknown notification policy %q for channel %s", policyName, name)
+		}
+		repo, err := newChannelNotificationRepository(run, name)
+		if err != nil {
+			return nil, err
+		}
+		repo = run.withLedger(repo, name)
+		channels = append(channels, service.NotificationChannel{Name: name, Repository: repo, Policy: policy})
+	}
+
+	if len(channels) == 1 && channels[0].Policy == service.NotificationRequired {
+		return channels[0].Repository, nil
+	}
+	return service.NewFanOutNotificationRepository(channels...), nil
+}
+
+func newChannelNotificationRepository(run jobRun, channel string) (service.NotificationRepository, error) {
+	switch channel {
+	case "line":
+		lineGroupID := os.Getenv("LINE_GROUP_ID")
+		lineChannelToken := os.Getenv("LINE_CHANNEL_TOKEN")
+		lineChannelSecret := os.Getenv("LINE_CHANNEL_SECRET")
+		return repository.NewLineNotificationRepository(lineGroupID, lineChannelSecret, lineChannelToken), nil
+	case "teams":
+		return repository.NewTeamsNotificationRepository(os.Getenv("TEAMS_WEBHOOK_URL")), nil
+	case "discord":
+		return repository.NewDiscordNotificationRepository(os.Getenv("DISCORD_WEBHOOK_URL")), nil
+	case "telegram":
+		threadID := 0
+		if value := os.Getenv("TELEGRAM_MESSAGE_THREAD_ID"); value != "" {
+			id, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid TELEGRAM_MESSAGE_THREAD_ID: %v", err)
+			}
+			threadID = id
+		}
+		return repository.NewTelegramNotificationRepository(repository.TelegramConfig{
+			BotToken:        os.Getenv("TELEGRAM_BOT_TOKEN"),
+			ChatID:          os.Getenv("TELEGRAM_CHAT_ID"),
+			MessageThreadID: threadID,
+			ParseMode:       os.Getenv("TELEGRAM_PARSE_MODE"),
+			APIURL:          os.Getenv("TELEGRAM_API_URL"),
+		}), nil
+	case "google_chat":
+		return repository.NewGoogleChatNotificationRepository(os.Getenv("GOOGLE_CHAT_WEBHOOK_URL"), run.scope.Date), nil
+	case "webhook":
+		timeout, err := time.ParseDuration(getEnvOrDefault("WEBHOOK_TIMEOUT", "10s"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid WEBHOOK_TIMEOUT: %v", err)
+		}
+		headers, err := parseHeaders(os.Getenv("WEBHOOK_HEADERS"))
+		if err != nil {
+			return nil, err
+		}
+		repo, err := repository.NewWebhookNotificationRepository(repository.WebhookConfig{
+			URLs:    splitList(os.Getenv("WEBHOOK_URLS")),
+			Secret:  os.Getenv("WEBHOOK_SECRET"),
+			Team:    os.Getenv("TEAM_NAME"),
+			Headers: headers,
+			Timeout: timeout,
+			Date:    run.scope.Date,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook config: %v", err)
+		}
+		return repo, nil
+	case "email":
+		port, err := strconv.Atoi(getEnvOrDefault("SMTP_PORT", "587"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid SMTP_PORT: %v", err)
+		}
+		return repository.NewEmailNotificationRepository(repository.SMTPConfig{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     port,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("SMTP_FROM"),
+			To:       splitList(os.Getenv("SMTP_TO")),
+			Subject:  os.Getenv("SMTP_SUBJECT"),
+			StartTLS: os.Getenv("SMTP_STARTTLS") != "false",
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown notification channel: %s", channel)
+	}
+}
+
+// jobRun is the job being run: its notifications are recorded in the ledger, when RUN_LEDGER is
+// set, under the team, the job's name and the roster date.
+type jobRun struct {
+	scope  service.RunScope
+	ledger service.RunLedger
+	// force sends even the messages the ledger has already recorded as delivered.
+	force bool
+	// eventRepositories holds the calendar of each slot, shared by every service of the run so an ICS
+	// feed read again is only revalidated.
+	eventRepositories map[string]service.EventRepository
+}
+
+// eventRepository returns the run's calendar for slot, creating it on first use.
+func (r jobRun) eventRepository(slot string) (service.EventRepository, error) {
+	if repo, ok := r.eventRepositories[slot]; ok {
+		return repo, nil
+	}
+	repo, err := newEventRepository(slot)
+	if err != nil {
+		return nil, err
+	}
+	r.eventRepositories[slot] = repo
+	return repo, nil
+}
+
+// withLedger makes a channel skip the messages it already delivered during an earlier attempt of
+// the same job on the same day. Each channel is recorded on its own so a retry after a partial
+// fan-out failure only sends to the channels that failed.
+func (r jobRun) withLedger(repo service.NotificationRepository, channel string) service.NotificationRepository {
+	if r.ledger == nil {
+		return repo
+	}
+	return service.NewLedgerNotificationRepository(repo, r.ledger, r.scope, channel, r.force)
+}
+
+// newRunLedger creates the ledger for RUN_LEDGER: "file:path", "sqlite:path" or "dynamodb:table".
+// RUN_LEDGER_DYNAMODB_ENDPOINT points the DynamoDB ledger at DynamoDB Local or another compatible store.
+func newRunLedger(value string) (service.RunLedger, error) {
+	backend, location, _ := strings.Cut(value, ":")
+	switch backend {
+	case "file":
+		return repository.NewFileRunLedger(location), nil
+	case "sqlite":
+		ledger, err := repository.NewSQLiteRunLedger(location)
+		if err != nil {
+			return nil, err
+		}
+		return ledger, nil
+	case "dynamodb":
+		ledger, err := repository.NewDynamoDBRunLedger(repository.DynamoDBConfig{
+			Table:    location,
+			Endpoint: os.Getenv("RUN_LEDGER_DYNAMODB_ENDPOINT"),
+			Region:   os.Getenv("RUN_LEDGER_DYNAMODB_REGION"),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return ledger, nil
+	default:
+		return nil, fmt.Errorf("unknown RUN_LEDGER backend: %s", backend)
+	}
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// splitList parses a comma separated environment variable, ignoring blank entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseHeaders parses "Name: value; Other-Name: value" into a header map.
+func parseHeaders(value string) (map[string]string, error) {
+	headers := map[string]string{}
+	for _, pair := range strings.Split(value, ";") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		name, headerValue, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid header %q, expected Name: value", strings.TrimSpace(pair))
+		}
+		headers[strings.TrimSpace(name)] = strings.TrimSpace(headerValue)
+	}
+	return headers, nil
+}
+
+// JobRequest is the Lambda event payload; the EventBridge schedule of each job sets its name,
+// e.g. {"job": "weekly"}. An empty payload runs the daily job; "force" sends even the messages
+// the run ledger has already recorded as delivered.
+type JobRequest struct {
+	Job   string `json:"job"`
+	Force bool   `json:"force"`
+}
+
+// runJob runs a scheduled job as of asOf: "daily" posts today's roster, the reminder before a
+// holiday or long weekend, the staffing early warning, on-call and leave conflicts, on-call
+// coverage gaps and the on-call handover, "tomorrow" the roster of the next working day and
+// "weekly" the weekly leave digest.
+func runJob(job string, asOf time.Time, force bool) error {
+	if job == "" {
+		job = "daily"
+	}
+	run := jobRun{
+		scope:             service.RunScope{Team: os.Getenv("TEAM_NAME"), Job: job, Date: asOf},
+		force:             force,
+		eventRepositories: map[string]service.EventRepository{},
+	}
+	if value := os.Getenv("RUN_LEDGER"); value != "" {
+		ledger, err := newRunLedger(value)
+		if err != nil {
+			return err
+		}
+		if closer, ok := ledger.(io.Closer); ok {
+			defer closer.Close()
+		}
+		run.ledger = ledger
+	}
+
+	switch job {
+	case "daily":
+		service, err := newEventNotifyServive(run)
+		if err != nil {
+			return fmt.Errorf("error creating event handler: %v", err)
+		}
+		if err := service.Notify(asOf); err != nil {
+			return err
+		}
+		// The roster is out: a failing follow-up is logged rather than failing the run, which
+		// would be retried and post the roster again.
+		followUps := []struct {
+			name   string
+			notify func() error
+		}{
+			{"break reminder", func() error { return service.NotifyUpcomingBreak(asOf) }},
+			{"capacity outlook", func() error { return service.NotifyCapacityOutlook(asOf) }},
+			{"on-call conflicts", func() error { return notifyOnCallConflicts(run, asOf) }},
+			{"on-call coverage gaps", func() error { return notifyOnCallCoverageGaps(run, asOf) }},
+			{"on-call handover", func() error { return notifyOnCallHandover(run, asOf) }},
+		}
+		for _, followUp := range followUps {
+			if err := followUp.notify(); err != nil {
+				log.Printf("Error sending %s: %v", followUp.name, err)
+			}
+		}
+		return nil
+	case "tomorrow":
+		service, err := newEventNotifyServive(run)
+		if err != nil {
+			return fmt.Errorf("error creating event handler: %v", err)
+		}
+		return service.NotifyNextWorkingDay(asOf)
+	case "weekly":
+		digest, err := newWeeklyLeaveDigestService(run)
+		if err != nil {
+			return err
+		}
+		return digest.Notify(asOf)
+	case "leave-report":
+		report, err := newLeaveReportService(run)
+		if err != nil {
+			return err
+		}
+		return report.Notify(asOf)
+	default:
+		return fmt.Errorf("unknown job: %s", job)
+	}
+}
 
+// Handle call from AWS Lambda
+func HandleRequest(ctx context.Context, request JobRequest) error {
+	log.Printf("Running Lambda hendler function, job : %s", request.Job)
 	bangkok, err := time.LoadLocation("Asia/Bangkok")
 	if err != nil {
 		log.Fatal("Error loading location ", err)
 	}
 	asOf := time.Now().In(bangkok)
-	err = service.Notify(asOf)
+	err = runJob(request.Job, asOf, request.Force)
 	if err != nil {
 		log.Printf("Error handling event: %v", err)
 		return err
@@ -56,6 +649,10 @@ func HandleRequest(ctx context.Context) error {
 }
 
 func main() {
+	job := flag.String("job", "daily", "job to run: daily, tomorrow, weekly or leave-report")
+	force := flag.Bool("force", false, "send even if the run ledger has already recorded the message as delivered")
+	flag.Parse()
+
 	isLabbda := os.Getenv("IS_LAMBDA")
 	if isLabbda == "true" {
 		log.Printf("Running in AWS Lambda")
@@ -66,16 +663,13 @@ func main() {
 		} else {
 			log.Println("Loaded .env file")
 		}
-		service, err := newEventNotifyServive()
-		if err != nil {
-			log.Printf("Unable to create event handler: %v", err)
-			log.Fatal("Error while create service ", err)
-		}
 		bangkok, err := time.LoadLocation("Asia/Bangkok")
 		if err != nil {
 			log.Fatal("Error loading location ", err)
 		}
 		asOf := time.Now().In(bangkok)
-		service.Notify(asOf)
+		if err := runJob(*job, asOf, *force); err != nil {
+			log.Printf("Error running %s job: %v", *job, err)
+		}
 	}
 }
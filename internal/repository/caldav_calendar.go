@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"gitbub.com/tsongpon/iris/internal/service"
+)
+
+type CalDAVConfig struct {
+	// CalendarURL is the collection URL, e.g. https://cloud.example.com/remote.php/dav/calendars/iris/leave/
+	CalendarURL string
+	Username    string
+	Password    string
+	// BearerToken is used instead of basic auth when set.
+	BearerToken string
+}
+
+// CalDAVCalendar reads events from a CalDAV calendar collection such as Nextcloud.
+type CalDAVCalendar struct {
+	config     CalDAVConfig
+	httpClient *http.Client
+}
+
+func NewCalDAVCalendar(config CalDAVConfig) CalDAVCalendar {
+	return CalDAVCalendar{config: config, httpClient: &http.Client{Timeout: 30 * time.Second}}
+}
+
+const calDAVTimeFormat = "20060102T150405Z"
+
+const calDAVQuery = `<?xml version="1.0" encoding="utf-8"?>
+<C:calendar-query xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">
+  <D:prop>
+    <D:getetag/>
+    <C:calendar-data/>
+  </D:prop>
+  <C:filter>
+    <C:comp-filter name="VCALENDAR">
+      <C:comp-filter name="VEVENT">
+        <C:time-range start="%s" end="%s"/>
+      </C:comp-filter>
+    </C:comp-filter>
+  </C:filter>
+</C:calendar-query>`
+
+type calDAVMultistatus struct {
+	Responses []calDAVResponse `xml:"DAV: response"`
+}
+
+type calDAVResponse struct {
+	Href      string           `xml:"DAV: href"`
+	Propstats []calDAVPropstat `xml:"DAV: propstat"`
+}
+
+type calDAVPropstat struct {
+	Status string     `xml:"DAV: status"`
+	Prop   calDAVProp `xml:"DAV: prop"`
+}
+
+type calDAVProp struct {
+	CalendarData string `xml:"urn:ietf:params:xml:ns:caldav calendar-data"`
+}
+
+func (c CalDAVCalendar) GetEvents(asOf time.Time) ([]string, error) {
+	log.Printf("Get event of : %s, from caldav : %s", asOf.Format(time.DateOnly), c.config.CalendarURL)
+	start, end := dayWindow(asOf)
+	events, err := c.eventsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return eventSummaries(events), nil
+}
+
+func (c CalDAVCalendar) GetEventsBetween(start, end time.Time) ([]string, error) {
+	log.Printf("Get event between : %s and %s, from caldav : %s", start.Format(time.RFC3339), end.Format(time.RFC3339), c.config.CalendarURL)
+	events, err := c.eventsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return datedEventSummaries(events, start.Location()), nil
+}
+
+func (c CalDAVCalendar) ListEvents(start, end time.Time) ([]service.Event, error) {
+	log.Printf("List events between : %s and %s, from caldav : %s", start.Format(time.RFC3339), end.Format(time.RFC3339), c.config.CalendarURL)
+	events, err := c.eventsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return serviceEvents(events, start.Location()), nil
+}
+
+// eventsBetween asks the server for the calendar objects with an event in the range and expands
+// them locally, so recurring events work even on servers without CALDAV:expand support.
+func (c CalDAVCalendar) eventsBetween(start, end time.Time) ([]calendarEvent, error) {
+	objects, err := c.query(start, end)
+	if err != nil {
+		return nil, err
+	}
+	var events []calendarEvent
+	for _, object := range objects {
+		cal, err := decodeICS(strings.NewReader(object))
+		if err != nil {
+			return nil, err
+		}
+		occurrences, err := expandICSEvents(cal, start, end)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, occurrences...)
+	}
+	return eventsOverlapping(events, start, end), nil
+}
+
+func (c CalDAVCalendar) query(start, end time.Time) ([]string, error) {
+	body := fmt.Sprintf(calDAVQuery, start.UTC().Format(calDAVTimeFormat), end.UTC().Format(calDAVTimeFormat))
+	req, err := http.NewRequest("REPORT", c.config.CalendarURL, bytes.NewBufferString(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create caldav request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	req.Header.Set("Depth", "1")
+	if c.config.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.BearerToken)
+	} else if c.config.Username != "" {
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query caldav calendar: %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read caldav response: %v", err)
+	}
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, fmt.Errorf("caldav calendar-query returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var multistatus calDAVMultistatus
+	if err := xml.Unmarshal(respBody, &multistatus); err != nil {
+		return nil, fmt.Errorf("failed to parse caldav response: %v", err)
+	}
+	var objects []string
+	for _, response := range multistatus.Responses {
+		for _, propstat := range response.Propstats {
+			if !strings.Contains(propstat.Status, " 200 ") || strings.TrimSpace(propstat.Prop.CalendarData) == "" {
+				continue
+			}
+			objects = append(objects, propstat.Prop.CalendarData)
+		}
+	}
+	return objects, nil
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// calDAVObject is a stored calendar resource with the span the stand-in server filters on.
+type calDAVObject struct {
+	href  string
+	start time.Time
+	end   time.Time
+	data  string
+}
+
+type calDAVTimeRange struct {
+	Start string `xml:"start,attr"`
+	End   string `xml:"end,attr"`
+}
+
+type calDAVQueryRequest struct {
+	TimeRange calDAVTimeRange `xml:"filter>comp-filter>comp-filter>time-range"`
+}
+
+// newCalDAVStandIn answers calendar-query REPORTs the way a CalDAV server would, returning the
+// objects whose span overlaps the requested time-range.
+func newCalDAVStandIn(t *testing.T, authorization string, objects []calDAVObject) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != authorization {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method != "REPORT" || r.Header.Get("Depth") != "1" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var query calDAVQueryRequest
+		if err := xml.Unmarshal(body, &query); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		start, _ := time.Parse(calDAVTimeFormat, query.TimeRange.Start)
+		end, _ := time.Parse(calDAVTimeFormat, query.TimeRange.End)
+
+		var b bytes.Buffer
+		b.WriteString(`<?xml version="1.0" encoding="utf-8"?><d:multistatus xmlns:d="DAV:" xmlns:cal="urn:ietf:params:xml:ns:caldav">`)
+		for _, object := range objects {
+			if !object.start.Before(end) || !object.end.After(start) {
+				continue
+			}
+			fmt.Fprintf(&b, `<d:response><d:href>%s</d:href><d:propstat><d:prop><d:getetag>"1"</d:getetag><cal:calendar-data>`, object.href)
+			xml.EscapeText(&b, []byte(object.data))
+			b.WriteString(`</cal:calendar-data></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>`)
+		}
+		b.WriteString(`</d:multistatus>`)
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		w.WriteHeader(http.StatusMultiStatus)
+		w.Write(b.Bytes())
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testCalDAVObjects() []calDAVObject {
+	wrap := func(event string) string {
+		return "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//Nextcloud//EN\r\n" + strings.ReplaceAll(event, "\n", "\r\n") + "END:VCALENDAR\r\n"
+	}
+	return []calDAVObject{
+		{
+			href:  "/remote.php/dav/calendars/iris/leave/john.ics",
+			start: time.Date(2025, 8, 11, 17, 0, 0, 0, time.UTC),
+			end:   time.Date(2025, 8, 13, 17, 0, 0, 0, time.UTC),
+			data:  wrap("BEGIN:VEVENT\nUID:john\nDTSTART;VALUE=DATE:20250812\nDTEND;VALUE=DATE:20250814\nSUMMARY:John Doe\nEND:VEVENT\n"),
+		},
+		{
+			href:  "/remote.php/dav/calendars/iris/leave/jane.ics",
+			start: time.Date(2025, 8, 20, 2, 0, 0, 0, time.UTC),
+			end:   time.Date(2025, 8, 20, 6, 0, 0, 0, time.UTC),
+			data:  wrap("BEGIN:VEVENT\nUID:jane\nDTSTART;TZID=Asia/Bangkok:20250820T090000\nDTEND;TZID=Asia/Bangkok:20250820T130000\nSUMMARY:Jane Doe (half day)\nEND:VEVENT\n"),
+		},
+		{
+			href:  "/remote.php/dav/calendars/iris/leave/weekly.ics",
+			start: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			end:   time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC),
+			data:  wrap("BEGIN:VEVENT\nUID:weekly\nDTSTART;VALUE=DATE:20250103\nRRULE:FREQ=WEEKLY;BYDAY=FR\nSUMMARY:Somchai (WFH Friday)\nEND:VEVENT\n"),
+		},
+	}
+}
+
+func TestCalDAVCalendar_GetEvents_BasicAuth(t *testing.T) {
+	// Arrange
+	server := newCalDAVStandIn(t, "Basic aXJpczpzZWNyZXQ=", testCalDAVObjects())
+	repo := NewCalDAVCalendar(CalDAVConfig{CalendarURL: server.URL + "/remote.php/dav/calendars/iris/leave/", Username: "iris", Password: "secret"})
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	// Act
+	tuesday, err1 := repo.GetEvents(time.Date(2025, 8, 12, 8, 0, 0, 0, bangkok))
+	friday, err2 := repo.GetEvents(time.Date(2025, 8, 15, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err1 != nil || err2 != nil {
+		t.Fatalf("Expected no error, got %v and %v", err1, err2)
+	}
+	if !reflect.DeepEqual(tuesday, []string{"John Doe"}) {
+		t.Errorf("Expected John Doe on leave, got %v", tuesday)
+	}
+	if !reflect.DeepEqual(friday, []string{"Somchai (WFH Friday)"}) {
+		t.Errorf("Expected weekly recurrence on Friday, got %v", friday)
+	}
+}
+
+func TestCalDAVCalendar_GetEventsBetween_BearerAuth(t *testing.T) {
+	// Arrange
+	server := newCalDAVStandIn(t, "Bearer t0ken", testCalDAVObjects())
+	repo := NewCalDAVCalendar(CalDAVConfig{CalendarURL: server.URL + "/remote.php/dav/calendars/iris/leave/", BearerToken: "t0ken"})
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	// Act
+	events, err := repo.GetEventsBetween(time.Date(2025, 8, 18, 0, 0, 0, 0, bangkok), time.Date(2025, 8, 23, 0, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []string{"2025-08-20: Jane Doe (half day)", "2025-08-22: Somchai (WFH Friday)"}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Expected %v, got %v", expected, events)
+	}
+}
+
+func TestCalDAVCalendar_GetEvents_Unauthorized(t *testing.T) {
+	// Arrange
+	server := newCalDAVStandIn(t, "Bearer t0ken", testCalDAVObjects())
+	repo := NewCalDAVCalendar(CalDAVConfig{CalendarURL: server.URL, Username: "iris", Password: "wrong"})
+
+	// Act
+	_, err := repo.GetEvents(time.Date(2025, 8, 12, 8, 0, 0, 0, time.UTC))
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "status 401") {
+		t.Errorf("Expected unauthorized error, got %v", err)
+	}
+}
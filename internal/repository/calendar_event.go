@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"sort"
+	"time"
+
+	"gitbub.com/tsongpon/iris/internal/service"
+)
+
+// calendarEvent is a single occurrence read from a calendar source other than Google Calendar.
+// The end is exclusive, matching iCalendar DTEND semantics.
+type calendarEvent struct {
+	summary string
+	start   time.Time
+	end     time.Time
+	allDay  bool
+}
+
+// dayWindow is the part of asOf's day that GetEvents looks at, shared by every event source
+// so they agree with GoogleCalendar on what counts as "today".
+func dayWindow(asOf time.Time) (time.Time, time.Time) {
+	beginningOfDay := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 9, 0, 0, 0, asOf.Location())
+	endOfDay := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 23, 59, 59, int(time.Second-time.Nanosecond), asOf.Location())
+	return beginningOfDay, endOfDay
+}
+
+// eventsOverlapping returns the events that overlap [start, end) ordered by start time, the
+// same selection Google Calendar makes for timeMin/timeMax.
+func eventsOverlapping(events []calendarEvent, start, end time.Time) []calendarEvent {
+	var overlapping []calendarEvent
+	for _, event := range events {
+		if event.start.Before(end) && event.end.After(start) {
+			overlapping = append(overlapping, event)
+		}
+	}
+	sort.SliceStable(overlapping, func(i, j int) bool {
+		return overlapping[i].start.Before(overlapping[j].start)
+	})
+	return overlapping
+}
+
+func eventSummaries(events []calendarEvent) []string {
+	var summaries []string
+	for _, event := range events {
+		summaries = append(summaries, event.summary)
+	}
+	return summaries
+}
+
+// datedEventSummaries formats events the way GetEventsBetween does: "2006-01-02: summary".
+func datedEventSummaries(events []calendarEvent, loc *time.Location) []string {
+	var summaries []string
+	for _, event := range events {
+		summaries = append(summaries, event.start.In(loc).Format(time.DateOnly)+": "+event.summary)
+	}
+	return summaries
+}
+
+// mergeContiguousEvents joins events of the same summary that overlap or touch, so a person whose
+// on-call shift is split across schedule layers or API pages is listed once.
+func mergeContiguousEvents(events []calendarEvent) []calendarEvent {
+	sorted := append([]calendarEvent{}, events...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].start.Before(sorted[j].start) })
+	var merged []calendarEvent
+	for _, event := range sorted {
+		joined := false
+		for i := range merged {
+			if merged[i].summary == event.summary && !event.start.After(merged[i].end) {
+				if event.end.After(merged[i].end) {
+					merged[i].end = event.end
+				}
+				joined = true
+				break
+			}
+		}
+		if !joined {
+			merged = append(merged, event)
+		}
+	}
+	return merged
+}
+
+// serviceEvents converts events to what ListEvents returns, in loc.
+func serviceEvents(events []calendarEvent, loc *time.Location) []service.Event {
+	var converted []service.Event
+	for _, event := range events {
+		converted = append(converted, service.Event{Summary: event.summary, Start: event.start.In(loc), End: event.end.In(loc), AllDay: event.allDay})
+	}
+	return converted
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// DirectoryLeaveReportStore keeps the leave report exports as files in a local directory, for
+// running on a single machine such as a cron job.
+type DirectoryLeaveReportStore struct {
+	dir string
+}
+
+func NewDirectoryLeaveReportStore(dir string) DirectoryLeaveReportStore {
+	return DirectoryLeaveReportStore{dir: dir}
+}
+
+func (d DirectoryLeaveReportStore) SaveLeaveReport(name string, csv []byte) error {
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(d.dir, name), csv, 0o644)
+}
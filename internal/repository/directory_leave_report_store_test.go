@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirectoryLeaveReportStore_SaveLeaveReport(t *testing.T) {
+	// Arrange
+	dir := filepath.Join(t.TempDir(), "reports")
+	store := NewDirectoryLeaveReportStore(dir)
+
+	// Act
+	err := store.SaveLeaveReport("leave-2025-07.csv", []byte("month,name\n"))
+	retryErr := store.SaveLeaveReport("leave-2025-07.csv", []byte("month,name\n2025-07,Alice\n"))
+
+	// Assert
+	if err != nil || retryErr != nil {
+		t.Fatalf("Expected no errors, got %v and %v", err, retryErr)
+	}
+	content, _ := os.ReadFile(filepath.Join(dir, "leave-2025-07.csv"))
+	if string(content) != "month,name\n2025-07,Alice\n" {
+		t.Errorf("Expected the export to be replaced, got %q", content)
+	}
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Discord webhook limits, see https://discord.com/developers/docs/resources/message#embed-object-embed-limits
+const (
+	discordMaxEmbedsPerMessage  = 10
+	discordMaxCharsPerMessage   = 6000
+	discordMaxTitleChars        = 256
+	discordMaxDescriptionChars  = 4096
+	discordColorHoliday         = 0x2ECC71
+	discordColorLeave           = 0xF1C40F
+	discordColorOnCall          = 0xE74C3C
+	discordColorDefault         = 0x95A5A6
+	discordContinuedTitleSuffix = " (ต่อ)"
+)
+
+// DiscordNotificationRepository posts the roster to a Discord webhook, one embed per section.
+type DiscordNotificationRepository struct {
+	webhookURL string
+	httpClient *http.Client
+}
+
+func NewDiscordNotificationRepository(webhookURL string) DiscordNotificationRepository {
+	return DiscordNotificationRepository{
+		webhookURL: webhookURL,
+		httpClient: &http.Client{Timeout: defaultWebhookTimeout},
+	}
+}
+
+type discordMessage struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Color       int    `json:"color"`
+}
+
+type discordRateLimit struct {
+	Message    string  `json:"message"`
+	RetryAfter float64 `json:"retry_after"`
+	Global     bool    `json:"global"`
+}
+
+func (d DiscordNotificationRepository) SendNotification(message string) error {
+	log.Printf("Sending message to Discord")
+	for _, msg := range buildDiscordMessages(splitSections(message)) {
+		if err := d.post(msg); err != nil {
+			log.Printf("Failed to send message: %v", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (d DiscordNotificationRepository) post(message discordMessage) error {
+	for attempt := 0; ; attempt++ {
+		status, header, body, err := postJSON(d.httpClient, d.webhookURL, message, nil)
+		if err != nil {
+			return err
+		}
+		if status == http.StatusTooManyRequests && attempt < maxRateLimitRetries {
+			// A 429 from a proxy in front of Discord, e.g. Cloudflare, has no JSON body.
+			wait := retryAfterHeader(header)
+			var rateLimit discordRateLimit
+			if err := json.Unmarshal(body, &rateLimit); err == nil && rateLimit.RetryAfter > 0 {
+				wait = capBackoff(time.Duration(rateLimit.RetryAfter * float64(time.Second)))
+			}
+			log.Printf("Discord webhook is rate limited, retrying in %v", wait)
+			sleep(wait)
+			continue
+		}
+		if status < 200 || status >= 300 {
+			return fmt.Errorf("discord webhook returned status %d: %s", status, strings.TrimSpace(string(body)))
+		}
+		return nil
+	}
+}
+
+// buildDiscordMessages creates one embed per section, splitting long sections over several
+// embeds and packing embeds into as many messages as the Discord limits require.
+func buildDiscordMessages(sections []messageSection) []discordMessage {
+	var messages []discordMessage
+	var current discordMessage
+	chars := 0
+	for _, section := range sections {
+		for _, embed := range discordEmbeds(section) {
+			size := utf8.RuneCountInString(embed.Title) + utf8.RuneCountInString(embed.Description)
+			if len(current.Embeds) == discordMaxEmbedsPerMessage || (len(current.Embeds) > 0 && chars+size > discordMaxCharsPerMessage) {
+				messages = append(messages, current)
+				current = discordMessage{}
+				chars = 0
+			}
+			current.Embeds = append(current.Embeds, embed)
+			chars += size
+		}
+	}
+	if len(current.Embeds) > 0 {
+		messages = append(messages, current)
+	}
+	return messages
+}
+
+func discordEmbeds(section messageSection) []discordEmbed {
+	title := truncateRunes(section.title, discordMaxTitleChars)
+	color := discordColor(section.kind())
+	continuedTitle := truncateRunes(section.title, discordMaxTitleChars-utf8.RuneCountInString(discordContinuedTitleSuffix)) + discordContinuedTitleSuffix
+	// Keep title plus description within what a single message can carry.
+	maxDescription := min(discordMaxDescriptionChars, discordMaxCharsPerMessage-discordMaxTitleChars)
+
+	var embeds []discordEmbed
+	var lines []string
+	chars := 0
+	flush := func() {
+		embedTitle := title
+		if len(embeds) > 0 {
+			embedTitle = continuedTitle
+		}
+		embeds = append(embeds, discordEmbed{Title: embedTitle, Description: strings.Join(lines, "\n"), Color: color})
+		lines = nil
+		chars = 0
+	}
+	for _, line := range section.lines {
+		line = truncateRunes(line, maxDescription)
+		size := utf8.RuneCountInString(line) + 1
+		if len(lines) > 0 && chars+size > maxDescription {
+			flush()
+		}
+		lines = append(lines, line)
+		chars += size
+	}
+	flush()
+	return embeds
+}
+
+func discordColor(kind sectionKind) int {
+	switch kind {
+	case sectionHoliday:
+		return discordColorHoliday
+	case sectionLeave:
+		return discordColorLeave
+	case sectionOnCall:
+		return discordColorOnCall
+	default:
+		return discordColorDefault
+	}
+}
+
+func truncateRunes(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:max-1]) + "…"
+}
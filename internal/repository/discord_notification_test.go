@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestDiscordNotificationRepository_SendNotification_ColorCodedEmbeds(t *testing.T) {
+	// Arrange
+	var received discordMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	repo := NewDiscordNotificationRepository(server.URL)
+
+	// Act
+	err := repo.SendNotification("วันนี้วันหยุด 🥳🏖️: (2025-08-12)\n- National Day\n\n📞 วันนี้ใคร On-Call : (2025-08-12)\n- Jane Doe")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(received.Embeds) != 2 {
+		t.Fatalf("Expected 2 embeds, got %d", len(received.Embeds))
+	}
+	if received.Embeds[0].Color != discordColorHoliday || received.Embeds[0].Description != "- National Day" {
+		t.Errorf("Unexpected holiday embed: %+v", received.Embeds[0])
+	}
+	if received.Embeds[1].Color != discordColorOnCall || received.Embeds[1].Title != "📞 วันนี้ใคร On-Call : (2025-08-12)" {
+		t.Errorf("Unexpected on-call embed: %+v", received.Embeds[1])
+	}
+}
+
+func TestDiscordNotificationRepository_SendNotification_WaitsRetryAfterFromBody(t *testing.T) {
+	// Arrange
+	var waited time.Duration
+	sleep = func(d time.Duration) { waited = d }
+	defer func() { sleep = time.Sleep }()
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"message": "You are being rate limited.", "retry_after": 1.5, "global": false}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	repo := NewDiscordNotificationRepository(server.URL)
+
+	// Act
+	err := repo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- John Doe")
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+	if waited != 1500*time.Millisecond {
+		t.Errorf("Expected to wait 1.5s, waited %v", waited)
+	}
+}
+
+func TestDiscordNotificationRepository_SendNotification_NonJSONRateLimitUsesRetryAfterHeader(t *testing.T) {
+	// Arrange
+	var waited time.Duration
+	sleep = func(d time.Duration) { waited = d }
+	defer func() { sleep = time.Sleep }()
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte("<html>error code: 1015</html>"))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	repo := NewDiscordNotificationRepository(server.URL)
+
+	// Act
+	err := repo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- John Doe")
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+	if waited != 2*time.Second {
+		t.Errorf("Expected to wait 2s, waited %v", waited)
+	}
+}
+
+func TestMessageSection_Kind_LeaveHeadings(t *testing.T) {
+	// Arrange
+	headings := map[string]sectionKind{
+		"📅 วันนี้ใครลา : (2025-08-12)":                   sectionLeave,
+		"🗓️ ใครลาสัปดาห์นี้ : (2025-08-11 - 2025-08-15)": sectionLeave,
+		"⚠️ ลาทั้งสัปดาห์":                               sectionLeave,
+		"⏰ เวลาทำงาน":                                    sectionOther,
+	}
+
+	for heading, expected := range headings {
+		// Act
+		kind := messageSection{title: heading}.kind()
+
+		// Assert
+		if kind != expected {
+			t.Errorf("Expected %q to be kind %d, got %d", heading, expected, kind)
+		}
+	}
+}
+
+func TestDiscordNotificationRepository_SendNotification_ErrorStatus(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message": "Invalid Form Body", "code": 50035}`))
+	}))
+	defer server.Close()
+	repo := NewDiscordNotificationRepository(server.URL)
+
+	// Act
+	err := repo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- John Doe")
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "status 400") {
+		t.Errorf("Expected status error, got %v", err)
+	}
+}
+
+func TestBuildDiscordMessages_RespectsLimits(t *testing.T) {
+	// Arrange
+	var lines []string
+	for i := 0; i < 1000; i++ {
+		lines = append(lines, "- สมชาย ใจดี (ลาพักร้อน)")
+	}
+	sections := []messageSection{{title: "📅 วันนี้ใครลา : (2025-08-12)", lines: lines}}
+
+	// Act
+	messages := buildDiscordMessages(sections)
+
+	// Assert
+	if len(messages) < 2 {
+		t.Fatalf("Expected message to be split, got %d message", len(messages))
+	}
+	total := 0
+	for _, message := range messages {
+		chars := 0
+		for _, embed := range message.Embeds {
+			if utf8.RuneCountInString(embed.Description) > discordMaxDescriptionChars {
+				t.Errorf("Expected description within limit, got %d chars", utf8.RuneCountInString(embed.Description))
+			}
+			chars += utf8.RuneCountInString(embed.Title) + utf8.RuneCountInString(embed.Description)
+			total += len(strings.Split(embed.Description, "\n"))
+		}
+		if chars > discordMaxCharsPerMessage || len(message.Embeds) > discordMaxEmbedsPerMessage {
+			t.Errorf("Expected message within limits, got %d chars in %d embeds", chars, len(message.Embeds))
+		}
+	}
+	if total != len(lines) {
+		t.Errorf("Expected %d lines delivered, got %d", len(lines), total)
+	}
+}
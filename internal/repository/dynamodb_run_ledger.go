@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gitbub.com/tsongpon/iris/internal/service"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// runLedgerRetention is how long DynamoDB keeps a delivery, through the table's TTL on expires_at.
+const runLedgerRetention = 90 * 24 * time.Hour
+
+type DynamoDBConfig struct {
+	// Table has a string partition key named pk.
+	Table string
+	// Endpoint overrides the AWS endpoint, e.g. http://localhost:8000 for DynamoDB Local.
+	Endpoint string
+	Region   string
+}
+
+// DynamoDBRunLedger keeps the delivered notifications in a DynamoDB table, for Lambda, where
+// the local disk does not outlive the invocation. Credentials come from the usual AWS chain.
+type DynamoDBRunLedger struct {
+	client *dynamodb.Client
+	table  string
+}
+
+func NewDynamoDBRunLedger(config DynamoDBConfig) (DynamoDBRunLedger, error) {
+	var options []func(*awsconfig.LoadOptions) error
+	if config.Region != "" {
+		options = append(options, awsconfig.WithRegion(config.Region))
+	}
+	awsConfig, err := awsconfig.LoadDefaultConfig(context.Background(), options...)
+	if err != nil {
+		return DynamoDBRunLedger{}, fmt.Errorf("failed to load AWS config: %v", err)
+	}
+	client := dynamodb.NewFromConfig(awsConfig, func(o *dynamodb.Options) {
+		if config.Endpoint != "" {
+			o.BaseEndpoint = aws.String(config.Endpoint)
+		}
+	})
+	return DynamoDBRunLedger{client: client, table: config.Table}, nil
+}
+
+func (d DynamoDBRunLedger) Delivered(key service.RunKey) (bool, error) {
+	output, err := d.client.GetItem(context.Background(), &dynamodb.GetItemInput{
+		TableName:      aws.String(d.table),
+		Key:            map[string]types.AttributeValue{"pk": &types.AttributeValueMemberS{Value: key.String()}},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(output.Item) > 0, nil
+}
+
+func (d DynamoDBRunLedger) RecordDelivered(key service.RunKey) error {
+	now := time.Now().UTC()
+	_, err := d.client.PutItem(context.Background(), &dynamodb.PutItemInput{
+		TableName: aws.String(d.table),
+		Item: map[string]types.AttributeValue{
+			"pk":           &types.AttributeValueMemberS{Value: key.String()},
+			"team":         &types.AttributeValueMemberS{Value: key.Team},
+			"job":          &types.AttributeValueMemberS{Value: key.Job},
+			"date":         &types.AttributeValueMemberS{Value: key.Date},
+			"channel":      &types.AttributeValueMemberS{Value: key.Channel},
+			"content_hash": &types.AttributeValueMemberS{Value: key.ContentHash},
+			"delivered_at": &types.AttributeValueMemberS{Value: now.Format(time.RFC3339)},
+			"expires_at":   &types.AttributeValueMemberN{Value: strconv.FormatInt(now.Add(runLedgerRetention).Unix(), 10)},
+		},
+	})
+	return err
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// newFakeDynamoDBServer serves GetItem and PutItem of the DynamoDB JSON protocol for table
+// run-ledger, like DynamoDB Local does.
+func newFakeDynamoDBServer(t *testing.T) *httptest.Server {
+	var mu sync.Mutex
+	items := map[string]map[string]map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			TableName string
+			Key       map[string]map[string]string
+			Item      map[string]map[string]string
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.TableName != "run-ledger" {
+			w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"__type":"com.amazonaws.dynamodb.v20120810#ResourceNotFoundException","message":"Requested resource not found"}`)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		switch r.Header.Get("X-Amz-Target") {
+		case "DynamoDB_20120810.GetItem":
+			item, ok := items[request.Key["pk"]["S"]]
+			if !ok {
+				fmt.Fprint(w, `{}`)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"Item": item})
+		case "DynamoDB_20120810.PutItem":
+			items[request.Item["pk"]["S"]] = request.Item
+			fmt.Fprint(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"__type":"com.amazon.coral.service#UnknownOperationException"}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestDynamoDBRunLedger(t *testing.T, table string) DynamoDBRunLedger {
+	t.Setenv("AWS_ACCESS_KEY_ID", "local")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "local")
+	ledger, err := NewDynamoDBRunLedger(DynamoDBConfig{Table: table, Endpoint: newFakeDynamoDBServer(t).URL, Region: "ap-southeast-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return ledger
+}
+
+func TestDynamoDBRunLedger_RecordDelivered(t *testing.T) {
+	// Arrange
+	ledger := newTestDynamoDBRunLedger(t, "run-ledger")
+	otherJob := testRunKey
+	otherJob.Job = "weekly"
+
+	// Act
+	before, beforeErr := ledger.Delivered(testRunKey)
+	recordErr := ledger.RecordDelivered(testRunKey)
+	after, afterErr := ledger.Delivered(testRunKey)
+	other, otherErr := ledger.Delivered(otherJob)
+
+	// Assert
+	if beforeErr != nil || recordErr != nil || afterErr != nil || otherErr != nil {
+		t.Fatalf("Expected no errors, got %v, %v, %v and %v", beforeErr, recordErr, afterErr, otherErr)
+	}
+	if before || !after || other {
+		t.Errorf("Expected only the recorded key to be delivered, got before=%v after=%v other job=%v", before, after, other)
+	}
+}
+
+func TestDynamoDBRunLedger_MissingTable(t *testing.T) {
+	// Arrange
+	ledger := newTestDynamoDBRunLedger(t, "missing")
+
+	// Act
+	_, err := ledger.Delivered(testRunKey)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+}
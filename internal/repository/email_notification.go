@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"html"
+	"log"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultEmailSubject = "Iris daily roster"
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+	Subject  string
+	// StartTLS upgrades the connection before authenticating and fails when the server does not offer it.
+	StartTLS bool
+}
+
+// EmailNotificationRepository mails the roster as a multipart text and HTML digest.
+type EmailNotificationRepository struct {
+	config SMTPConfig
+	// rootCAs verifies the server certificate after STARTTLS; nil uses the system roots.
+	rootCAs *x509.CertPool
+}
+
+func NewEmailNotificationRepository(config SMTPConfig) EmailNotificationRepository {
+	if config.Subject == "" {
+		config.Subject = defaultEmailSubject
+	}
+	return EmailNotificationRepository{config: config}
+}
+
+func (e EmailNotificationRepository) SendNotification(message string) error {
+	if len(e.config.To) == 0 {
+		return fmt.Errorf("no email recipients configured")
+	}
+	mail, err := buildEmail(e.config, message, time.Now())
+	if err != nil {
+		log.Printf("Failed to build email: %v", err)
+		return err
+	}
+
+	log.Printf("Sending email to %d recipients", len(e.config.To))
+	if err := e.send(mail); err != nil {
+		log.Printf("Failed to send email: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (e EmailNotificationRepository) send(mail []byte) error {
+	addr := net.JoinHostPort(e.config.Host, strconv.Itoa(e.config.Port))
+	client, err := smtp.Dial(addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %v", err)
+	}
+	defer client.Close()
+
+	if e.config.StartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("SMTP server %s does not support STARTTLS", addr)
+		}
+		if err := client.StartTLS(&tls.Config{ServerName: e.config.Host, RootCAs: e.rootCAs}); err != nil {
+			return fmt.Errorf("failed to start TLS: %v", err)
+		}
+	}
+	if e.config.Username != "" {
+		auth := smtp.PlainAuth("", e.config.Username, e.config.Password, e.config.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("failed to authenticate: %v", err)
+		}
+	}
+
+	if err := client.Mail(e.config.From); err != nil {
+		return fmt.Errorf("failed to set sender: %v", err)
+	}
+	for _, to := range e.config.To {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("failed to add recipient %s: %v", to, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start message data: %v", err)
+	}
+	if _, err := w.Write(mail); err != nil {
+		return fmt.Errorf("failed to write message: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to finish message: %v", err)
+	}
+	return client.Quit()
+}
+
+func buildEmail(config SMTPConfig, message string, date time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	header := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\nMIME-Version: 1.0\r\nContent-Type: multipart/alternative; boundary=%q\r\n\r\n",
+		config.From, strings.Join(config.To, ", "), mime.QEncoding.Encode("utf-8", config.Subject),
+		date.Format(time.RFC1123Z), parts.Boundary())
+
+	if err := writeQuotedPrintablePart(parts, "text/plain; charset=utf-8", message); err != nil {
+		return nil, err
+	}
+	if err := writeQuotedPrintablePart(parts, "text/html; charset=utf-8", renderEmailHTML(splitSections(message))); err != nil {
+		return nil, err
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+	return append([]byte(header), body.Bytes()...), nil
+}
+
+func writeQuotedPrintablePart(parts *multipart.Writer, contentType, content string) error {
+	part, err := parts.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create %s part: %v", contentType, err)
+	}
+	qp := quotedprintable.NewWriter(part)
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return fmt.Errorf("failed to write %s part: %v", contentType, err)
+	}
+	return qp.Close()
+}
+
+// renderEmailHTML renders each section as a heading followed by a list of its "- " items.
+func renderEmailHTML(sections []messageSection) string {
+	var b strings.Builder
+	b.WriteString("<html><body>")
+	for _, section := range sections {
+		b.WriteString("<h3>" + html.EscapeString(section.title) + "</h3>")
+		inList := false
+		for _, line := range section.lines {
+			item, isItem := strings.CutPrefix(line, "- ")
+			if isItem && !inList {
+				b.WriteString("<ul>")
+			} else if !isItem && inList {
+				b.WriteString("</ul>")
+			}
+			inList = isItem
+			if isItem {
+				b.WriteString("<li>" + html.EscapeString(item) + "</li>")
+			} else {
+				b.WriteString("<p>" + html.EscapeString(line) + "</p>")
+			}
+		}
+		if inList {
+			b.WriteString("</ul>")
+		}
+	}
+	b.WriteString("</body></html>")
+	return b.String()
+}
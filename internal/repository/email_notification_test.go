@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"io"
+	"math/big"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSMTPServer is a minimal in-process SMTP server that records one delivered message.
+type fakeSMTPServer struct {
+	listener   net.Listener
+	extensions []string
+	auth       string
+	from       string
+	recipients []string
+	data       string
+	// tlsConfig answers STARTTLS when set; startedTLS records that the client upgraded.
+	tlsConfig  *tls.Config
+	startedTLS bool
+	done       chan struct{}
+}
+
+func newFakeSMTPServer(t *testing.T, extensions ...string) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unable to start fake SMTP server: %v", err)
+	}
+	s := &fakeSMTPServer{listener: listener, extensions: extensions, done: make(chan struct{})}
+	go s.serve()
+	t.Cleanup(func() { listener.Close() })
+	return s
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) serve() {
+	defer close(s.done)
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer func() { conn.Close() }()
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 localhost fake SMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch command {
+		case "EHLO":
+			tp.PrintfLine("250-localhost")
+			for _, ext := range s.extensions {
+				tp.PrintfLine("250-%s", ext)
+			}
+			tp.PrintfLine("250 8BITMIME")
+		case "STARTTLS":
+			tp.PrintfLine("220 Ready to start TLS")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			tp = textproto.NewConn(conn)
+			s.startedTLS = true
+		case "AUTH":
+			decoded, _ := base64.StdEncoding.DecodeString(strings.Fields(line)[2])
+			s.auth = string(decoded)
+			tp.PrintfLine("235 Authentication successful")
+		case "MAIL":
+			s.from = envelopeAddress(line)
+			tp.PrintfLine("250 OK")
+		case "RCPT":
+			s.recipients = append(s.recipients, envelopeAddress(line))
+			tp.PrintfLine("250 OK")
+		case "DATA":
+			tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, _ := io.ReadAll(tp.DotReader())
+			s.data = string(data)
+			tp.PrintfLine("250 OK")
+		case "QUIT":
+			tp.PrintfLine("221 Bye")
+			return
+		default:
+			tp.PrintfLine("250 OK")
+		}
+	}
+}
+
+// envelopeAddress extracts the address from "MAIL FROM:<a@b> ..." and "RCPT TO:<a@b>" commands.
+func envelopeAddress(line string) string {
+	_, rest, _ := strings.Cut(line, "<")
+	address, _, _ := strings.Cut(rest, ">")
+	return address
+}
+
+func TestEmailNotificationRepository_SendNotification_DeliversMultipartDigest(t *testing.T) {
+	// Arrange
+	server := newFakeSMTPServer(t, "AUTH PLAIN")
+	repo := NewEmailNotificationRepository(SMTPConfig{
+		Host:     "127.0.0.1",
+		Port:     server.port(),
+		Username: "iris",
+		Password: "secret",
+		From:     "iris@example.com",
+		To:       []string{"manager@example.com", "lead@example.com"},
+	})
+
+	// Act
+	err := repo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- John Doe\n\n📞 วันนี้ใคร On-Call : (2025-08-12)\n- Jane <Ops>")
+	<-server.done
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if server.auth != "\x00iris\x00secret" {
+		t.Errorf("Expected PLAIN auth with configured credentials, got %q", server.auth)
+	}
+	if server.from != "iris@example.com" {
+		t.Errorf("Expected sender iris@example.com, got %s", server.from)
+	}
+	if strings.Join(server.recipients, ",") != "manager@example.com,lead@example.com" {
+		t.Errorf("Unexpected recipients %v", server.recipients)
+	}
+
+	msg, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(server.data)))
+	if err != nil {
+		t.Fatalf("Unable to parse delivered message: %v", err)
+	}
+	subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if subject != "Iris daily roster" {
+		t.Errorf("Expected default subject, got %s", subject)
+	}
+	_, params, _ := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+
+	textPart, _ := reader.NextPart()
+	text, _ := io.ReadAll(textPart)
+	expectedText := "📅 วันนี้ใครลา : (2025-08-12)\n- John Doe\n\n📞 วันนี้ใคร On-Call : (2025-08-12)\n- Jane <Ops>"
+	if string(text) != expectedText {
+		t.Errorf("Expected text part '%s', got '%s'", expectedText, text)
+	}
+
+	htmlPart, _ := reader.NextPart()
+	htmlBody, _ := io.ReadAll(htmlPart)
+	expectedHTML := "<html><body><h3>📅 วันนี้ใครลา : (2025-08-12)</h3><ul><li>John Doe</li></ul>" +
+		"<h3>📞 วันนี้ใคร On-Call : (2025-08-12)</h3><ul><li>Jane &lt;Ops&gt;</li></ul></body></html>"
+	if string(htmlBody) != expectedHTML {
+		t.Errorf("Expected HTML part '%s', got '%s'", expectedHTML, htmlBody)
+	}
+}
+
+// newSelfSignedCertificate creates a certificate for 127.0.0.1 and a pool that trusts it.
+func newSelfSignedCertificate(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unable to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "fake SMTP"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Unable to create certificate: %v", err)
+	}
+	certificate, _ := x509.ParseCertificate(der)
+	pool := x509.NewCertPool()
+	pool.AddCert(certificate)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, pool
+}
+
+func TestEmailNotificationRepository_SendNotification_StartTLS(t *testing.T) {
+	// Arrange
+	certificate, pool := newSelfSignedCertificate(t)
+	server := newFakeSMTPServer(t, "STARTTLS", "AUTH PLAIN")
+	server.tlsConfig = &tls.Config{Certificates: []tls.Certificate{certificate}}
+	repo := NewEmailNotificationRepository(SMTPConfig{
+		Host:     "127.0.0.1",
+		Port:     server.port(),
+		Username: "iris",
+		Password: "secret",
+		From:     "iris@example.com",
+		To:       []string{"manager@example.com"},
+		StartTLS: true,
+	})
+	repo.rootCAs = pool
+
+	// Act
+	err := repo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- John Doe")
+	<-server.done
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !server.startedTLS {
+		t.Error("Expected the connection to be upgraded with STARTTLS")
+	}
+	if server.auth != "\x00iris\x00secret" || server.from != "iris@example.com" || server.data == "" {
+		t.Errorf("Expected the message to be delivered over TLS, got auth %q from %q", server.auth, server.from)
+	}
+}
+
+func TestEmailNotificationRepository_SendNotification_StartTLSUntrustedCertificate(t *testing.T) {
+	// Arrange
+	certificate, _ := newSelfSignedCertificate(t)
+	server := newFakeSMTPServer(t, "STARTTLS")
+	server.tlsConfig = &tls.Config{Certificates: []tls.Certificate{certificate}}
+	repo := NewEmailNotificationRepository(SMTPConfig{
+		Host:     "127.0.0.1",
+		Port:     server.port(),
+		From:     "iris@example.com",
+		To:       []string{"manager@example.com"},
+		StartTLS: true,
+	})
+
+	// Act
+	err := repo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- John Doe")
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "failed to start TLS") {
+		t.Errorf("Expected TLS error, got %v", err)
+	}
+}
+
+func TestEmailNotificationRepository_SendNotification_StartTLSNotOffered(t *testing.T) {
+	// Arrange
+	server := newFakeSMTPServer(t)
+	repo := NewEmailNotificationRepository(SMTPConfig{
+		Host:     "127.0.0.1",
+		Port:     server.port(),
+		From:     "iris@example.com",
+		To:       []string{"manager@example.com"},
+		StartTLS: true,
+	})
+
+	// Act
+	err := repo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- John Doe")
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "does not support STARTTLS") {
+		t.Errorf("Expected STARTTLS error, got %v", err)
+	}
+}
+
+func TestEmailNotificationRepository_SendNotification_NoRecipients(t *testing.T) {
+	// Arrange
+	repo := NewEmailNotificationRepository(SMTPConfig{Host: "127.0.0.1", Port: 25, From: "iris@example.com"})
+
+	// Act
+	err := repo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- John Doe")
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"gitbub.com/tsongpon/iris/internal/service"
+)
+
+// FileRunLedger keeps the delivered notifications as JSON lines in a local file, for running on a
+// single machine such as a cron job.
+type FileRunLedger struct {
+	path string
+}
+
+type runLedgerRecord struct {
+	Team        string    `json:"team"`
+	Job         string    `json:"job"`
+	Date        string    `json:"date"`
+	Channel     string    `json:"channel"`
+	ContentHash string    `json:"content_hash"`
+	DeliveredAt time.Time `json:"delivered_at"`
+}
+
+func (r runLedgerRecord) key() service.RunKey {
+	return service.RunKey{Team: r.Team, Job: r.Job, Date: r.Date, Channel: r.Channel, ContentHash: r.ContentHash}
+}
+
+func NewFileRunLedger(path string) FileRunLedger {
+	return FileRunLedger{path: path}
+}
+
+func (f FileRunLedger) Delivered(key service.RunKey) (bool, error) {
+	file, err := os.Open(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record runLedgerRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return false, fmt.Errorf("invalid run ledger record %q: %v", scanner.Text(), err)
+		}
+		if record.key() == key {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+func (f FileRunLedger) RecordDelivered(key service.RunKey) error {
+	line, err := json.Marshal(runLedgerRecord{
+		Team:        key.Team,
+		Job:         key.Job,
+		Date:        key.Date,
+		Channel:     key.Channel,
+		ContentHash: key.ContentHash,
+		DeliveredAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+
+	"gitbub.com/tsongpon/iris/internal/service"
+)
+
+var testRunKey = service.RunKey{Team: "backend", Job: "daily", Date: "2025-08-12", Channel: "line", ContentHash: "3f2a"}
+
+func TestFileRunLedger_RecordDelivered(t *testing.T) {
+	// Arrange
+	ledger := NewFileRunLedger(filepath.Join(t.TempDir(), "ledger.jsonl"))
+	otherDay := testRunKey
+	otherDay.Date = "2025-08-13"
+
+	// Act
+	before, beforeErr := ledger.Delivered(testRunKey)
+	recordErr := ledger.RecordDelivered(testRunKey)
+	after, afterErr := ledger.Delivered(testRunKey)
+	other, otherErr := ledger.Delivered(otherDay)
+
+	// Assert
+	if beforeErr != nil || recordErr != nil || afterErr != nil || otherErr != nil {
+		t.Fatalf("Expected no errors, got %v, %v, %v and %v", beforeErr, recordErr, afterErr, otherErr)
+	}
+	if before || !after || other {
+		t.Errorf("Expected only the recorded key to be delivered, got before=%v after=%v other day=%v", before, after, other)
+	}
+}
+
+func TestFileRunLedger_SurvivesReopen(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "ledger.jsonl")
+	if err := NewFileRunLedger(path).RecordDelivered(testRunKey); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Act
+	delivered, err := NewFileRunLedger(path).Delivered(testRunKey)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !delivered {
+		t.Error("Expected the delivery to be read back from the file")
+	}
+}
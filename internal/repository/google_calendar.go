@@ -7,6 +7,7 @@ import (
 	"log"
 	"time"
 
+	"gitbub.com/tsongpon/iris/internal/service"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/calendar/v3"
 	"google.golang.org/api/option"
@@ -25,31 +26,20 @@ func NewGoogleCalendar(base64GoogleCalendarCredential, calendarID string) Google
 }
 
 func (g GoogleCalendar) GetEvents(asOf time.Time) ([]string, error) {
-	ctx := context.Background()
-	credential, err := base64.StdEncoding.DecodeString(g.base64GoogleCalendarCredential)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode base64 credential: %v", err)
-	}
-
-	config, err := google.JWTConfigFromJSON(credential, calendar.CalendarReadonlyScope)
-	if err != nil {
-		log.Fatalf("Unable to parse client secret file to config: %v", err)
-	}
-
-	client := config.Client(ctx)
-	srv, err := calendar.NewService(ctx, option.WithHTTPClient(client))
+	srv, err := g.calendarService(context.Background())
 	if err != nil {
-		log.Fatalf("Unable to retrieve Calendar client: %v", err)
+		return nil, err
 	}
 
-	beginningOfDay := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 9, 0, 0, 0, asOf.Location()).Format(time.RFC3339)
-	endOfDay := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 23, 59, 59, int(time.Second-time.Nanosecond), asOf.Location()).Format(time.RFC3339)
+	dayStart, dayEnd := dayWindow(asOf)
+	beginningOfDay := dayStart.Format(time.RFC3339)
+	endOfDay := dayEnd.Format(time.RFC3339)
 	log.Printf("Get event of : %s, from calendar : %s", asOf.Format(time.DateOnly), g.calendarID)
 
 	todayLeavesEvent, err := srv.Events.List(g.calendarID).ShowDeleted(false).
 		SingleEvents(true).TimeMin(beginningOfDay).TimeMax(endOfDay).MaxResults(50).OrderBy("startTime").Do()
 	if err != nil {
-		log.Fatalf("Unable to retrieve next ten of the user's events: %v", err)
+		return nil, fmt.Errorf("failed to list events of calendar %s: %v", g.calendarID, err)
 	}
 
 	var eventSummaries []string
@@ -61,21 +51,9 @@ func (g GoogleCalendar) GetEvents(asOf time.Time) ([]string, error) {
 }
 
 func (g GoogleCalendar) GetEventsBetween(start, end time.Time) ([]string, error) {
-	ctx := context.Background()
-	credential, err := base64.StdEncoding.DecodeString(g.base64GoogleCalendarCredential)
+	srv, err := g.calendarService(context.Background())
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode base64 credential: %v", err)
-	}
-
-	config, err := google.JWTConfigFromJSON(credential, calendar.CalendarReadonlyScope)
-	if err != nil {
-		log.Fatalf("Unable to parse client secret file to config: %v", err)
-	}
-
-	client := config.Client(ctx)
-	srv, err := calendar.NewService(ctx, option.WithHTTPClient(client))
-	if err != nil {
-		log.Fatalf("Unable to retrieve Calendar client: %v", err)
+		return nil, err
 	}
 
 	beginningOfPeriod := start.Format(time.RFC3339)
@@ -85,14 +63,89 @@ func (g GoogleCalendar) GetEventsBetween(start, end time.Time) ([]string, error)
 	events, err := srv.Events.List(g.calendarID).ShowDeleted(false).
 		SingleEvents(true).TimeMin(beginningOfPeriod).TimeMax(endOfPeriod).MaxResults(50).OrderBy("startTime").Do()
 	if err != nil {
-		log.Fatalf("Unable to retrieve next ten of the user's events: %v", err)
+		return nil, fmt.Errorf("failed to list events of calendar %s: %v", g.calendarID, err)
 	}
 
-	var eventSummaries []string
+	// Timed events have no Start.Date, so they are dated from their start time in start's zone.
+	var calendarEvents []calendarEvent
 	for _, item := range events.Items {
-		date := item.Start.Date
-		eventSummaries = append(eventSummaries, date+": "+item.Summary)
+		event, err := convertGoogleEvent(item, start.Location())
+		if err != nil {
+			return nil, err
+		}
+		calendarEvents = append(calendarEvents, event)
 	}
 
-	return eventSummaries, nil
+	return datedEventSummaries(calendarEvents, start.Location()), nil
+}
+
+func (g GoogleCalendar) ListEvents(start, end time.Time) ([]service.Event, error) {
+	ctx := context.Background()
+	srv, err := g.calendarService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("List events between : %s and %s, from calendar : %s", start.Format(time.RFC3339), end.Format(time.RFC3339), g.calendarID)
+
+	var events []calendarEvent
+	err = srv.Events.List(g.calendarID).ShowDeleted(false).
+		SingleEvents(true).TimeMin(start.Format(time.RFC3339)).TimeMax(end.Format(time.RFC3339)).OrderBy("startTime").
+		Pages(ctx, func(page *calendar.Events) error {
+			for _, item := range page.Items {
+				event, err := convertGoogleEvent(item, start.Location())
+				if err != nil {
+					return err
+				}
+				events = append(events, event)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events of calendar %s: %v", g.calendarID, err)
+	}
+	return serviceEvents(eventsOverlapping(events, start, end), start.Location()), nil
+}
+
+func (g GoogleCalendar) calendarService(ctx context.Context) (*calendar.Service, error) {
+	credential, err := base64.StdEncoding.DecodeString(g.base64GoogleCalendarCredential)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64 credential: %v", err)
+	}
+	config, err := google.JWTConfigFromJSON(credential, calendar.CalendarReadonlyScope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse google credential: %v", err)
+	}
+	srv, err := calendar.NewService(ctx, option.WithHTTPClient(config.Client(ctx)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create calendar client: %v", err)
+	}
+	return srv, nil
+}
+
+// convertGoogleEvent reads timed events from their RFC 3339 dateTime and anchors all-day events,
+// which only carry a date, to midnight in loc.
+func convertGoogleEvent(item *calendar.Event, loc *time.Location) (calendarEvent, error) {
+	if item.Start == nil || item.End == nil {
+		return calendarEvent{}, fmt.Errorf("event %q has no start or end", item.Summary)
+	}
+	if item.Start.DateTime == "" {
+		start, err := time.ParseInLocation(time.DateOnly, item.Start.Date, loc)
+		if err != nil {
+			return calendarEvent{}, fmt.Errorf("invalid start of event %q: %v", item.Summary, err)
+		}
+		end, err := time.ParseInLocation(time.DateOnly, item.End.Date, loc)
+		if err != nil {
+			return calendarEvent{}, fmt.Errorf("invalid end of event %q: %v", item.Summary, err)
+		}
+		return calendarEvent{summary: item.Summary, start: start, end: end, allDay: true}, nil
+	}
+	start, err := time.Parse(time.RFC3339, item.Start.DateTime)
+	if err != nil {
+		return calendarEvent{}, fmt.Errorf("invalid start of event %q: %v", item.Summary, err)
+	}
+	end, err := time.Parse(time.RFC3339, item.End.DateTime)
+	if err != nil {
+		return calendarEvent{}, fmt.Errorf("invalid end of event %q: %v", item.Summary, err)
+	}
+	return calendarEvent{summary: item.Summary, start: start, end: end}, nil
 }
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const googleChatCardID = "iris-roster"
+
+// GoogleChatNotificationRepository posts cardsV2 messages to a Google Chat space incoming webhook.
+// Messages for the same roster date share a thread key, so follow-ups such as the end-of-month
+// holiday list land in the same thread as the daily roster, even when a retry runs after midnight.
+type GoogleChatNotificationRepository struct {
+	webhookURL string
+	date       time.Time
+	httpClient *http.Client
+}
+
+func NewGoogleChatNotificationRepository(webhookURL string, date time.Time) GoogleChatNotificationRepository {
+	return GoogleChatNotificationRepository{
+		webhookURL: webhookURL,
+		date:       date,
+		httpClient: &http.Client{Timeout: defaultWebhookTimeout},
+	}
+}
+
+type googleChatMessage struct {
+	Text    string           `json:"text,omitempty"`
+	CardsV2 []googleChatCard `json:"cardsV2"`
+	Thread  googleChatThread `json:"thread"`
+}
+
+type googleChatThread struct {
+	ThreadKey string `json:"threadKey"`
+}
+
+type googleChatCard struct {
+	CardID string             `json:"cardId"`
+	Card   googleChatCardBody `json:"card"`
+}
+
+type googleChatCardBody struct {
+	Sections []googleChatSection `json:"sections"`
+}
+
+type googleChatSection struct {
+	Header  string             `json:"header,omitempty"`
+	Widgets []googleChatWidget `json:"widgets"`
+}
+
+type googleChatWidget struct {
+	TextParagraph googleChatTextParagraph `json:"textParagraph"`
+}
+
+type googleChatTextParagraph struct {
+	Text string `json:"text"`
+}
+
+type googleChatError struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	} `json:"error"`
+}
+
+func (g GoogleChatNotificationRepository) SendNotification(message string) error {
+	threadKey := g.threadKey()
+	webhookURL, err := threadedWebhookURL(g.webhookURL)
+	if err != nil {
+		return err
+	}
+	payload := buildGoogleChatMessage(splitSections(message), threadKey)
+
+	log.Printf("Sending message to Google Chat thread %s", threadKey)
+	for attempt := 0; ; attempt++ {
+		status, header, body, err := postJSON(g.httpClient, webhookURL, payload, nil)
+		if err != nil {
+			log.Printf("Failed to send message: %v", err)
+			return err
+		}
+		if status == http.StatusTooManyRequests && attempt < maxRateLimitRetries {
+			wait := retryAfterHeader(header)
+			log.Printf("Google Chat webhook is rate limited, retrying in %v", wait)
+			sleep(wait)
+			continue
+		}
+		if status < 200 || status >= 300 {
+			err = googleChatResponseError(status, body)
+			log.Printf("Failed to send message: %v", err)
+			return err
+		}
+		return nil
+	}
+}
+
+// threadKey is stable for a roster date.
+func (g GoogleChatNotificationRepository) threadKey() string {
+	return "iris-" + g.date.Format(time.DateOnly)
+}
+
+// threadedWebhookURL asks Chat to reply in the keyed thread, starting it when it does not exist yet.
+func threadedWebhookURL(webhookURL string) (string, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid google chat webhook url: %v", err)
+	}
+	query := u.Query()
+	query.Set("messageReplyOption", "REPLY_MESSAGE_FALLBACK_TO_NEW_THREAD")
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+func googleChatResponseError(status int, body []byte) error {
+	var chatErr googleChatError
+	if err := json.Unmarshal(body, &chatErr); err == nil && chatErr.Error.Message != "" {
+		return fmt.Errorf("google chat webhook returned %s (%d): %s", chatErr.Error.Status, status, chatErr.Error.Message)
+	}
+	return fmt.Errorf("google chat webhook returned status %d: %s", status, strings.TrimSpace(string(body)))
+}
+
+// buildGoogleChatMessage renders every section as a card section; the plain text fallback is
+// what shows up in notifications and clients that cannot render cards, so it is only the first
+// heading rather than a copy of the card.
+func buildGoogleChatMessage(sections []messageSection, threadKey string) googleChatMessage {
+	var cardSections []googleChatSection
+	for _, section := range sections {
+		var lines []string
+		for _, line := range section.lines {
+			lines = append(lines, html.EscapeString(line))
+		}
+		// Chat rejects sections without widgets, so a heading-only section becomes a bold paragraph.
+		cardSection := googleChatSection{Header: section.title}
+		text := strings.Join(lines, "<br>")
+		if len(lines) == 0 {
+			cardSection.Header = ""
+			text = "<b>" + html.EscapeString(section.title) + "</b>"
+		}
+		cardSection.Widgets = []googleChatWidget{{TextParagraph: googleChatTextParagraph{Text: text}}}
+		cardSections = append(cardSections, cardSection)
+	}
+	var text string
+	if len(sections) > 0 {
+		text = sections[0].title
+	}
+	return googleChatMessage{
+		Text:    text,
+		CardsV2: []googleChatCard{{CardID: googleChatCardID, Card: googleChatCardBody{Sections: cardSections}}},
+		Thread:  googleChatThread{ThreadKey: threadKey},
+	}
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGoogleChatNotificationRepository_SendNotification_SameThreadForTheDay(t *testing.T) {
+	// Arrange
+	var received []googleChatMessage
+	var replyOptions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message googleChatMessage
+		json.NewDecoder(r.Body).Decode(&message)
+		received = append(received, message)
+		replyOptions = append(replyOptions, r.URL.Query().Get("messageReplyOption"))
+		w.Write([]byte(`{"name": "spaces/AAA/messages/BBB"}`))
+	}))
+	defer server.Close()
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	repo := NewGoogleChatNotificationRepository(server.URL+"/v1/spaces/AAA/messages?key=k&token=t", time.Date(2025, 8, 31, 8, 30, 0, 0, bangkok))
+
+	// Act
+	err1 := repo.SendNotification("มีวันหยุด 1 วันเดือน กันยายน 🎉🏖️:\n- 2025-09-01: Holiday")
+	err2 := repo.SendNotification("📞 วันนี้ใคร On-Call : (2025-08-31)\n- Jane <Doe>\n\n📅 วันนี้ใครลา : (2025-08-31)\n- John Doe")
+
+	// Assert
+	if err1 != nil || err2 != nil {
+		t.Fatalf("Expected no error, got %v and %v", err1, err2)
+	}
+	if received[0].Thread.ThreadKey != "iris-2025-08-31" || received[1].Thread.ThreadKey != "iris-2025-08-31" {
+		t.Errorf("Expected both messages in thread iris-2025-08-31, got %s and %s", received[0].Thread.ThreadKey, received[1].Thread.ThreadKey)
+	}
+	if replyOptions[0] != "REPLY_MESSAGE_FALLBACK_TO_NEW_THREAD" {
+		t.Errorf("Expected reply option to be set, got %s", replyOptions[0])
+	}
+	section := received[1].CardsV2[0].Card.Sections[0]
+	if section.Header != "📞 วันนี้ใคร On-Call : (2025-08-31)" || section.Widgets[0].TextParagraph.Text != "- Jane &lt;Doe&gt;" {
+		t.Errorf("Unexpected card section: %+v", section)
+	}
+	if received[1].Text != "📞 วันนี้ใคร On-Call : (2025-08-31)" {
+		t.Errorf("Expected the first heading as fallback text, got %q", received[1].Text)
+	}
+}
+
+func TestGoogleChatNotificationRepository_SendNotification_ErrorResponse(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": {"code": 400, "message": "Invalid JSON payload", "status": "INVALID_ARGUMENT"}}`))
+	}))
+	defer server.Close()
+	repo := NewGoogleChatNotificationRepository(server.URL, time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC))
+
+	// Act
+	err := repo.SendNotification("เดือน กันยายน ไม่มีวันหยุด 💪😢")
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "INVALID_ARGUMENT") {
+		t.Errorf("Expected INVALID_ARGUMENT error, got %v", err)
+	}
+}
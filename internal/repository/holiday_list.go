@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gitbub.com/tsongpon/iris/internal/service"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed data/holidays.yaml
+var embeddedHolidays []byte
+
+// HolidayList serves holidays from a YAML or CSV list maintained by HR, either the file embedded
+// at build time or one loaded from disk. It needs no network access.
+type HolidayList struct {
+	source   string
+	holidays []listedHoliday
+	warned   *sync.Once
+}
+
+// listedHoliday spans the dates first..last inclusive.
+type listedHoliday struct {
+	name  string
+	first time.Time
+	last  time.Time
+}
+
+type holidayListFile struct {
+	Holidays []struct {
+		Date string `yaml:"date"`
+		End  string `yaml:"end"`
+		Name string `yaml:"name"`
+	} `yaml:"holidays"`
+}
+
+// NewHolidayList loads the list at path, or the embedded list when path is empty.
+func NewHolidayList(path string) (HolidayList, error) {
+	if path == "" {
+		holidays, err := parseHolidayYAML(embeddedHolidays)
+		if err != nil {
+			return HolidayList{}, fmt.Errorf("invalid embedded holiday list: %v", err)
+		}
+		return HolidayList{source: "embedded holidays.yaml", holidays: holidays, warned: &sync.Once{}}, nil
+	}
+	holidays, err := readHolidayFile(path)
+	if err != nil {
+		return HolidayList{}, err
+	}
+	return HolidayList{source: path, holidays: holidays, warned: &sync.Once{}}, nil
+}
+
+// readHolidayFile reads a holiday list from disk. Files ending in .csv have a "date,name" header
+// and an optional "end" column; anything else is read as YAML.
+func readHolidayFile(path string) ([]listedHoliday, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read holiday list: %v", err)
+	}
+	var holidays []listedHoliday
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		holidays, err = parseHolidayCSV(data)
+	} else {
+		holidays, err = parseHolidayYAML(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid holiday list %s: %v", path, err)
+	}
+	return holidays, nil
+}
+
+func (h HolidayList) GetEvents(asOf time.Time) ([]string, error) {
+	log.Printf("Get event of : %s, from holiday list : %s", asOf.Format(time.DateOnly), h.source)
+	h.warnIfComingYearMissing(asOf)
+	start, end := dayWindow(asOf)
+	return eventSummaries(eventsOverlapping(h.events(asOf.Location()), start, end)), nil
+}
+
+func (h HolidayList) GetEventsBetween(start, end time.Time) ([]string, error) {
+	log.Printf("Get event between : %s and %s, from holiday list : %s", start.Format(time.RFC3339), end.Format(time.RFC3339), h.source)
+	h.warnIfComingYearMissing(start)
+	return datedEventSummaries(eventsOverlapping(h.events(start.Location()), start, end), start.Location()), nil
+}
+
+func (h HolidayList) ListEvents(start, end time.Time) ([]service.Event, error) {
+	log.Printf("List events between : %s and %s, from holiday list : %s", start.Format(time.RFC3339), end.Format(time.RFC3339), h.source)
+	h.warnIfComingYearMissing(start)
+	return serviceEvents(eventsOverlapping(h.events(start.Location()), start, end), start.Location()), nil
+}
+
+func (h HolidayList) events(loc *time.Location) []calendarEvent {
+	return listedHolidayEvents(h.holidays, loc)
+}
+
+// listedHolidayEvents anchors the listed dates to whole days in loc.
+func listedHolidayEvents(holidays []listedHoliday, loc *time.Location) []calendarEvent {
+	var events []calendarEvent
+	for _, holiday := range holidays {
+		start := time.Date(holiday.first.Year(), holiday.first.Month(), holiday.first.Day(), 0, 0, 0, 0, loc)
+		end := time.Date(holiday.last.Year(), holiday.last.Month(), holiday.last.Day()+1, 0, 0, 0, 0, loc)
+		events = append(events, calendarEvent{summary: holiday.name, start: start, end: end, allDay: true})
+	}
+	return events
+}
+
+// warnIfComingYearMissing logs once per list when HR has not published next year's holidays yet.
+func (h HolidayList) warnIfComingYearMissing(asOf time.Time) {
+	comingYear := asOf.Year() + 1
+	for _, holiday := range h.holidays {
+		if holiday.last.Year() >= comingYear {
+			return
+		}
+	}
+	h.warned.Do(func() {
+		log.Printf("WARNING: holiday list %s has no entries for %d", h.source, comingYear)
+	})
+}
+
+func parseHolidayYAML(data []byte) ([]listedHoliday, error) {
+	var file holidayListFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	var holidays []listedHoliday
+	for _, entry := range file.Holidays {
+		holiday, err := newListedHoliday(entry.Date, entry.End, entry.Name)
+		if err != nil {
+			return nil, err
+		}
+		holidays = append(holidays, holiday)
+	}
+	return holidays, nil
+}
+
+func parseHolidayCSV(data []byte) ([]listedHoliday, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("missing header: %v", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	dateColumn, hasDate := columns["date"]
+	nameColumn, hasName := columns["name"]
+	if !hasDate || !hasName {
+		return nil, fmt.Errorf("header must have date and name columns, got %v", header)
+	}
+	endColumn, hasEnd := columns["end"]
+
+	var holidays []listedHoliday
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		end := ""
+		if hasEnd && endColumn < len(record) {
+			end = record[endColumn]
+		}
+		if dateColumn >= len(record) || nameColumn >= len(record) {
+			return nil, fmt.Errorf("incomplete record %v", record)
+		}
+		holiday, err := newListedHoliday(record[dateColumn], end, record[nameColumn])
+		if err != nil {
+			return nil, err
+		}
+		holidays = append(holidays, holiday)
+	}
+	return holidays, nil
+}
+
+func newListedHoliday(date, end, name string) (listedHoliday, error) {
+	first, err := time.Parse(time.DateOnly, strings.TrimSpace(date))
+	if err != nil {
+		return listedHoliday{}, fmt.Errorf("invalid date of holiday %q: %v", name, err)
+	}
+	last := first
+	if strings.TrimSpace(end) != "" {
+		last, err = time.Parse(time.DateOnly, strings.TrimSpace(end))
+		if err != nil {
+			return listedHoliday{}, fmt.Errorf("invalid end of holiday %q: %v", name, err)
+		}
+		if last.Before(first) {
+			return listedHoliday{}, fmt.Errorf("holiday %q ends before it starts", name)
+		}
+	}
+	if strings.TrimSpace(name) == "" {
+		return listedHoliday{}, fmt.Errorf("holiday on %s has no name", date)
+	}
+	return listedHoliday{name: strings.TrimSpace(name), first: first, last: last}, nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHolidayList_Embedded(t *testing.T) {
+	// Arrange
+	list, err := NewHolidayList("")
+	if err != nil {
+		t.Fatalf("Expected embedded list to load, got %v", err)
+	}
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	// Act
+	songkran, err := list.GetEvents(time.Date(2025, 4, 15, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(songkran, []string{"วันสงกรานต์"}) {
+		t.Errorf("Expected Songkran, got %v", songkran)
+	}
+}
+
+func TestHolidayList_CSVFromDisk(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "holidays.csv")
+	csv := "date,end,name\n# HR list 2025\n2025-08-12,,Mother's Day\n2025-08-14,2025-08-15,Company outing\n"
+	os.WriteFile(path, []byte(csv), 0o600)
+	list, err := NewHolidayList(path)
+	if err != nil {
+		t.Fatalf("Expected list to load, got %v", err)
+	}
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	// Act
+	august, err := list.GetEventsBetween(time.Date(2025, 8, 1, 0, 0, 0, 0, bangkok), time.Date(2025, 8, 31, 0, 0, 0, 0, bangkok))
+	friday, err2 := list.GetEvents(time.Date(2025, 8, 15, 8, 0, 0, 0, bangkok))
+	saturday, err3 := list.GetEvents(time.Date(2025, 8, 16, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil || err2 != nil || err3 != nil {
+		t.Fatalf("Expected no error, got %v, %v and %v", err, err2, err3)
+	}
+	expected := []string{"2025-08-12: Mother's Day", "2025-08-14: Company outing"}
+	if !reflect.DeepEqual(august, expected) {
+		t.Errorf("Expected %v, got %v", expected, august)
+	}
+	if !reflect.DeepEqual(friday, []string{"Company outing"}) {
+		t.Errorf("Expected multi-day holiday to include its end date, got %v", friday)
+	}
+	if len(saturday) != 0 {
+		t.Errorf("Expected no holiday after the end date, got %v", saturday)
+	}
+}
+
+func TestHolidayList_WarnsWhenComingYearMissing(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "holidays.yaml")
+	os.WriteFile(path, []byte("holidays:\n  - date: 2025-12-31\n    name: New Year's Eve\n"), 0o600)
+	list, _ := NewHolidayList(path)
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	// Act
+	list.GetEvents(time.Date(2024, 12, 2, 8, 0, 0, 0, time.UTC))
+	before := logs.String()
+	list.GetEvents(time.Date(2025, 12, 1, 8, 0, 0, 0, time.UTC))
+	list.GetEvents(time.Date(2025, 12, 2, 8, 0, 0, 0, time.UTC))
+
+	// Assert
+	if strings.Contains(before, "WARNING") {
+		t.Errorf("Expected no warning while the coming year is listed, got %s", before)
+	}
+	if strings.Count(logs.String(), "has no entries for 2026") != 1 {
+		t.Errorf("Expected a single warning for 2026, got %s", logs.String())
+	}
+}
+
+func TestNewHolidayList_InvalidDate(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "holidays.yaml")
+	os.WriteFile(path, []byte("holidays:\n  - date: 2025-13-01\n    name: Broken\n"), 0o600)
+
+	// Act
+	_, err := NewHolidayList(path)
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
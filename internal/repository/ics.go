@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-ical"
+	"github.com/teambition/rrule-go"
+)
+
+func decodeICS(r io.Reader) (*ical.Calendar, error) {
+	cal, err := ical.NewDecoder(r).Decode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse iCalendar data: %v", err)
+	}
+	return cal, nil
+}
+
+// expandICSEvents returns the occurrences of the calendar's events that overlap [start, end).
+// Recurring events are expanded from RRULE, RDATE and EXDATE, and occurrences that were moved or
+// cancelled through a RECURRENCE-ID override are replaced by the override. All-day events and
+// floating times are read in start's location.
+func expandICSEvents(cal *ical.Calendar, start, end time.Time) ([]calendarEvent, error) {
+	loc := start.Location()
+
+	// Collect overrides first so the master's expansion can skip the occurrences they replace.
+	overridden := map[string][]time.Time{}
+	var events []calendarEvent
+	var masters []ical.Event
+	for _, event := range cal.Events() {
+		recurrenceID := event.Props.Get(ical.PropRecurrenceID)
+		if recurrenceID == nil {
+			masters = append(masters, event)
+			continue
+		}
+		uid, _ := event.Props.Text(ical.PropUID)
+		replaced, _, err := parseICSTime(recurrenceID, recurrenceID.Value, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RECURRENCE-ID of event %s: %v", uid, err)
+		}
+		overridden[uid] = append(overridden[uid], replaced)
+		if isCancelled(event) {
+			continue
+		}
+		occurrence, err := singleICSEvent(event, loc)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, occurrence)
+	}
+
+	for _, event := range masters {
+		if isCancelled(event) {
+			continue
+		}
+		first, err := singleICSEvent(event, loc)
+		if err != nil {
+			return nil, err
+		}
+		if event.Props.Get(ical.PropRecurrenceRule) == nil && len(event.Props.Values(ical.PropRecurrenceDates)) == 0 {
+			events = append(events, first)
+			continue
+		}
+		uid, _ := event.Props.Text(ical.PropUID)
+		occurrences, err := expandRecurrence(event, first, overridden[uid], start, end, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recurrence of event %s: %v", uid, err)
+		}
+		events = append(events, occurrences...)
+	}
+	return eventsOverlapping(events, start, end), nil
+}
+
+func singleICSEvent(event ical.Event, loc *time.Location) (calendarEvent, error) {
+	summary, _ := event.Props.Text(ical.PropSummary)
+	startProp := event.Props.Get(ical.PropDateTimeStart)
+	if startProp == nil {
+		return calendarEvent{}, fmt.Errorf("event %q has no DTSTART", summary)
+	}
+	start, allDay, err := parseICSTime(startProp, startProp.Value, loc)
+	if err != nil {
+		return calendarEvent{}, fmt.Errorf("invalid DTSTART of event %q: %v", summary, err)
+	}
+
+	end := start
+	if allDay {
+		end = start.AddDate(0, 0, 1)
+	}
+	if endProp := event.Props.Get(ical.PropDateTimeEnd); endProp != nil {
+		end, _, err = parseICSTime(endProp, endProp.Value, loc)
+		if err != nil {
+			return calendarEvent{}, fmt.Errorf("invalid DTEND of event %q: %v", summary, err)
+		}
+	} else if durationProp := event.Props.Get(ical.PropDuration); durationProp != nil {
+		duration, err := durationProp.Duration()
+		if err != nil {
+			return calendarEvent{}, fmt.Errorf("invalid DURATION of event %q: %v", summary, err)
+		}
+		end = start.Add(duration)
+	}
+	return calendarEvent{summary: summary, start: start, end: end, allDay: allDay}, nil
+}
+
+func expandRecurrence(event ical.Event, first calendarEvent, overridden []time.Time, start, end time.Time, loc *time.Location) ([]calendarEvent, error) {
+	set := rrule.Set{}
+	if ruleProp := event.Props.Get(ical.PropRecurrenceRule); ruleProp != nil {
+		option, err := rrule.StrToROptionInLocation(ruleProp.Value, loc)
+		if err != nil {
+			return nil, err
+		}
+		option.Dtstart = first.start
+		rule, err := rrule.NewRRule(*option)
+		if err != nil {
+			return nil, err
+		}
+		set.RRule(rule)
+	} else {
+		set.RDate(first.start)
+	}
+	for _, prop := range event.Props.Values(ical.PropRecurrenceDates) {
+		if prop.Params.Get("VALUE") == "PERIOD" {
+			continue
+		}
+		dates, err := parseICSTimeList(&prop, loc)
+		if err != nil {
+			return nil, err
+		}
+		for _, date := range dates {
+			set.RDate(date)
+		}
+	}
+	for _, prop := range event.Props.Values(ical.PropExceptionDates) {
+		dates, err := parseICSTimeList(&prop, loc)
+		if err != nil {
+			return nil, err
+		}
+		for _, date := range dates {
+			set.ExDate(date)
+		}
+	}
+	for _, date := range overridden {
+		set.ExDate(date)
+	}
+
+	// Occurrences that started before the window can still overlap it.
+	var occurrences []calendarEvent
+	for _, occurrenceStart := range set.Between(start.Add(-first.end.Sub(first.start)), end, true) {
+		occurrence := first
+		occurrence.start = occurrenceStart
+		if first.allDay {
+			days := int(first.end.Sub(first.start).Hours()+12) / 24
+			occurrence.end = occurrenceStart.AddDate(0, 0, days)
+		} else {
+			occurrence.end = occurrenceStart.Add(first.end.Sub(first.start))
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+	return occurrences, nil
+}
+
+// parseICSTime parses a DATE or DATE-TIME value of prop. Dates and floating times are read in loc,
+// and the TZID parameter may name an IANA or Windows time zone.
+func parseICSTime(prop *ical.Prop, value string, loc *time.Location) (time.Time, bool, error) {
+	if prop.Params.Get("VALUE") == "DATE" || len(value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+	if tzid := prop.Params.Get(ical.PropTimezoneID); tzid != "" {
+		tzLoc, err := loadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		loc = tzLoc
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, err
+}
+
+// parseICSTimeList parses the comma separated values of an EXDATE or RDATE property.
+func parseICSTimeList(prop *ical.Prop, loc *time.Location) ([]time.Time, error) {
+	var times []time.Time
+	for _, value := range strings.Split(prop.Value, ",") {
+		t, _, err := parseICSTime(prop, strings.TrimSpace(value), loc)
+		if err != nil {
+			return nil, err
+		}
+		times = append(times, t)
+	}
+	return times, nil
+}
+
+func isCancelled(event ical.Event) bool {
+	status, _ := event.Props.Text(ical.PropStatus)
+	return strings.EqualFold(status, "CANCELLED")
+}
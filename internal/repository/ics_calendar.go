@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gitbub.com/tsongpon/iris/internal/service"
+	"github.com/emersion/go-ical"
+)
+
+// ICSCalendar reads events from an iCalendar (.ics) file or HTTP(S) feed.
+type ICSCalendar struct {
+	source     string
+	httpClient *http.Client
+	cache      *icsCache
+}
+
+// icsCache keeps the last feed body with its validators so repeated reads can use conditional requests.
+type icsCache struct {
+	mu           sync.Mutex
+	etag         string
+	lastModified string
+	body         []byte
+}
+
+// NewICSCalendar creates a repository for source, which is a file path or an http(s):// or webcal:// URL.
+func NewICSCalendar(source string) ICSCalendar {
+	if strings.HasPrefix(source, "webcal://") {
+		source = "https://" + strings.TrimPrefix(source, "webcal://")
+	}
+	return ICSCalendar{
+		source:     source,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		cache:      &icsCache{},
+	}
+}
+
+func (c ICSCalendar) GetEvents(asOf time.Time) ([]string, error) {
+	log.Printf("Get event of : %s, from ics : %s", asOf.Format(time.DateOnly), c.source)
+	start, end := dayWindow(asOf)
+	events, err := c.eventsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return eventSummaries(events), nil
+}
+
+func (c ICSCalendar) GetEventsBetween(start, end time.Time) ([]string, error) {
+	log.Printf("Get event between : %s and %s, from ics : %s", start.Format(time.RFC3339), end.Format(time.RFC3339), c.source)
+	events, err := c.eventsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return datedEventSummaries(events, start.Location()), nil
+}
+
+func (c ICSCalendar) ListEvents(start, end time.Time) ([]service.Event, error) {
+	log.Printf("List events between : %s and %s, from ics : %s", start.Format(time.RFC3339), end.Format(time.RFC3339), c.source)
+	events, err := c.eventsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return serviceEvents(events, start.Location()), nil
+}
+
+func (c ICSCalendar) eventsBetween(start, end time.Time) ([]calendarEvent, error) {
+	body, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	cal, err := decodeICS(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	return expandICSEvents(cal, start, end)
+}
+
+func (c ICSCalendar) load() ([]byte, error) {
+	if !strings.HasPrefix(c.source, "http://") && !strings.HasPrefix(c.source, "https://") {
+		body, err := os.ReadFile(c.source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ics file: %v", err)
+		}
+		return body, nil
+	}
+	return c.fetch()
+}
+
+// fetch downloads the feed, revalidating a cached copy with If-None-Match/If-Modified-Since.
+func (c ICSCalendar) fetch() ([]byte, error) {
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+
+	req, err := http.NewRequest(http.MethodGet, c.source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ics request: %v", err)
+	}
+	req.Header.Set("Accept", ical.MIMEType)
+	if c.cache.body != nil {
+		if c.cache.etag != "" {
+			req.Header.Set("If-None-Match", c.cache.etag)
+		}
+		if c.cache.lastModified != "" {
+			req.Header.Set("If-Modified-Since", c.cache.lastModified)
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ics feed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && c.cache.body != nil:
+		return c.cache.body, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("ics feed returned status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ics feed: %v", err)
+	}
+	c.cache.body = body
+	c.cache.etag = resp.Header.Get("ETag")
+	c.cache.lastModified = resp.Header.Get("Last-Modified")
+	return body, nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testICS = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//iris//test//EN
+BEGIN:VEVENT
+UID:songkran
+DTSTART;VALUE=DATE:20250413
+DTEND;VALUE=DATE:20250416
+SUMMARY:Songkran
+END:VEVENT
+BEGIN:VEVENT
+UID:standup
+DTSTART;TZID=SE Asia Standard Time:20250804T100000
+DTEND;TZID=SE Asia Standard Time:20250804T103000
+RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;UNTIL=20250829T030000Z
+EXDATE;TZID=SE Asia Standard Time:20250812T100000
+SUMMARY:Standup
+END:VEVENT
+BEGIN:VEVENT
+UID:standup
+RECURRENCE-ID;TZID=SE Asia Standard Time:20250813T100000
+DTSTART;TZID=SE Asia Standard Time:20250813T140000
+DTEND;TZID=SE Asia Standard Time:20250813T143000
+SUMMARY:Standup (moved)
+END:VEVENT
+BEGIN:VEVENT
+UID:early
+DTSTART:20250813T000000Z
+DTEND:20250813T010000Z
+SUMMARY:Early call
+END:VEVENT
+BEGIN:VEVENT
+UID:birthday
+DTSTART;VALUE=DATE:20200101
+RRULE:FREQ=YEARLY
+SUMMARY:Company anniversary
+END:VEVENT
+END:VCALENDAR
+`
+
+func writeTestICS(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "calendar.ics")
+	if err := os.WriteFile(path, []byte(strings.ReplaceAll(testICS, "\n", "\r\n")), 0o600); err != nil {
+		t.Fatalf("Unable to write ics file: %v", err)
+	}
+	return path
+}
+
+func TestICSCalendar_GetEvents_ExpandsRecurrenceWithExceptions(t *testing.T) {
+	// Arrange
+	repo := NewICSCalendar(writeTestICS(t))
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	// Act
+	monday, err1 := repo.GetEvents(time.Date(2025, 8, 11, 8, 0, 0, 0, bangkok))
+	tuesday, err2 := repo.GetEvents(time.Date(2025, 8, 12, 8, 0, 0, 0, bangkok))
+	wednesday, err3 := repo.GetEvents(time.Date(2025, 8, 13, 8, 0, 0, 0, bangkok))
+	afterUntil, err4 := repo.GetEvents(time.Date(2025, 9, 1, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	for _, err := range []error{err1, err2, err3, err4} {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if !reflect.DeepEqual(monday, []string{"Standup"}) {
+		t.Errorf("Expected Monday standup, got %v", monday)
+	}
+	if len(tuesday) != 0 {
+		t.Errorf("Expected EXDATE to remove Tuesday's standup, got %v", tuesday)
+	}
+	// The early call ends at 08:00 Bangkok time, before the 09:00 start of the daily window.
+	if !reflect.DeepEqual(wednesday, []string{"Standup (moved)"}) {
+		t.Errorf("Expected only the moved standup on Wednesday, got %v", wednesday)
+	}
+	if len(afterUntil) != 0 {
+		t.Errorf("Expected no standup after UNTIL, got %v", afterUntil)
+	}
+}
+
+func TestICSCalendar_GetEventsBetween_AllDayEvents(t *testing.T) {
+	// Arrange
+	repo := NewICSCalendar(writeTestICS(t))
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	// Act
+	april, err := repo.GetEventsBetween(time.Date(2025, 4, 1, 0, 0, 0, 0, bangkok), time.Date(2025, 4, 30, 0, 0, 0, 0, bangkok))
+	january, err2 := repo.GetEventsBetween(time.Date(2026, 1, 1, 0, 0, 0, 0, bangkok), time.Date(2026, 1, 31, 0, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil || err2 != nil {
+		t.Fatalf("Expected no error, got %v and %v", err, err2)
+	}
+	if !reflect.DeepEqual(april, []string{"2025-04-13: Songkran"}) {
+		t.Errorf("Expected Songkran, got %v", april)
+	}
+	if !reflect.DeepEqual(january, []string{"2026-01-01: Company anniversary"}) {
+		t.Errorf("Expected yearly recurrence, got %v", january)
+	}
+}
+
+func TestICSCalendar_GetEvents_RevalidatesWithETag(t *testing.T) {
+	// Arrange
+	requests := 0
+	notModified := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(testICS))
+	}))
+	defer server.Close()
+	repo := NewICSCalendar(server.URL + "/holidays.ics")
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	// Act
+	first, err1 := repo.GetEvents(time.Date(2025, 4, 14, 8, 0, 0, 0, bangkok))
+	second, err2 := repo.GetEvents(time.Date(2025, 4, 15, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err1 != nil || err2 != nil {
+		t.Fatalf("Expected no error, got %v and %v", err1, err2)
+	}
+	if requests != 2 || notModified != 1 {
+		t.Errorf("Expected second request to be revalidated, got %d requests and %d not modified", requests, notModified)
+	}
+	if !reflect.DeepEqual(first, []string{"Songkran"}) || !reflect.DeepEqual(second, []string{"Songkran"}) {
+		t.Errorf("Expected Songkran from cached feed, got %v and %v", first, second)
+	}
+}
@@ -0,0 +1,67 @@
+package repository
+
+import "strings"
+
+// messageSection is one block of a rendered notification, e.g. the holiday list or the on-call list.
+// The service renders blocks separated by a blank line, with a heading line followed by "- item" lines.
+type messageSection struct {
+	title string
+	lines []string
+}
+
+func splitSections(message string) []messageSection {
+	var sections []messageSection
+	for _, block := range strings.Split(message, "\n\n") {
+		block = strings.TrimSpace(block)
+		if block == "" {
+			continue
+		}
+		lines := strings.Split(block, "\n")
+		sections = append(sections, messageSection{title: lines[0], lines: lines[1:]})
+	}
+	return sections
+}
+
+// text renders the section back into the plain text form produced by the service.
+func (s messageSection) text() string {
+	if len(s.lines) == 0 {
+		return s.title
+	}
+	return s.title + "\n" + strings.Join(s.lines, "\n")
+}
+
+type sectionKind int
+
+const (
+	sectionOther sectionKind = iota
+	sectionHoliday
+	sectionLeave
+	sectionOnCall
+)
+
+// leaveHeadings are the wordings of the leave headings in the service messages. A bare "ลา" would
+// also match words such as "เวลา".
+var leaveHeadings = []string{"ใครลา", "วันลา", "ลาทั้งสัปดาห์"}
+
+// kind classifies a section by the heading wording used in the service messages.
+func (s messageSection) kind() sectionKind {
+	switch {
+	case strings.Contains(s.title, "On-Call"):
+		return sectionOnCall
+	case strings.Contains(s.title, "วันหยุด"):
+		return sectionHoliday
+	case s.isLeave():
+		return sectionLeave
+	default:
+		return sectionOther
+	}
+}
+
+func (s messageSection) isLeave() bool {
+	for _, heading := range leaveHeadings {
+		if strings.Contains(s.title, heading) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,284 @@
+package repository
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"gitbub.com/tsongpon/iris/internal/service"
+	"gopkg.in/yaml.v3"
+)
+
+// OnCallRotation computes who is on call from a roster and rotation rules instead of reading a
+// calendar, so the same date always resolves to the same person.
+type OnCallRotation struct {
+	source      string
+	members     []string
+	weekly      bool
+	first       time.Time // date of the first shift, at midnight UTC
+	handoffHour int
+	handoffMin  int
+	// skipWeekends moves daily handoffs off Saturday and Sunday, so Friday's shift runs until Monday.
+	skipWeekends bool
+	location     *time.Location
+	unavailable  []rotationAbsence
+	overrides    []rotationOverride
+}
+
+// rotationAbsence skips member for every shift that overlaps the dates first..last inclusive.
+type rotationAbsence struct {
+	member string
+	first  time.Time
+	last   time.Time
+}
+
+type rotationOverride struct {
+	member string
+	start  string
+	end    string
+}
+
+type rotationFile struct {
+	Members []string `yaml:"members"`
+	// Handoff is "weekly" or "daily".
+	Handoff     string `yaml:"handoff"`
+	HandoffDay  string `yaml:"handoff_day"`
+	HandoffTime string `yaml:"handoff_time"`
+	// Start is the date the first member's first shift begins.
+	Start        string `yaml:"start"`
+	SkipWeekends bool   `yaml:"skip_weekends"`
+	TimeZone     string `yaml:"time_zone"`
+	Skip         []struct {
+		Member string `yaml:"member"`
+		From   string `yaml:"from"`
+		To     string `yaml:"to"`
+	} `yaml:"skip"`
+	Overrides []struct {
+		Member string `yaml:"member"`
+		Start  string `yaml:"start"`
+		End    string `yaml:"end"`
+	} `yaml:"overrides"`
+}
+
+const rotationOverrideLayout = "2006-01-02 15:04"
+
+// NewOnCallRotation loads the rotation config at path.
+func NewOnCallRotation(path string) (OnCallRotation, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return OnCallRotation{}, fmt.Errorf("failed to read on-call rotation: %v", err)
+	}
+	rotation, err := parseOnCallRotation(data)
+	if err != nil {
+		return OnCallRotation{}, fmt.Errorf("invalid on-call rotation %s: %v", path, err)
+	}
+	rotation.source = path
+	return rotation, nil
+}
+
+func parseOnCallRotation(data []byte) (OnCallRotation, error) {
+	var file rotationFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return OnCallRotation{}, err
+	}
+	if len(file.Members) == 0 {
+		return OnCallRotation{}, fmt.Errorf("rotation has no members")
+	}
+	rotation := OnCallRotation{members: file.Members, skipWeekends: file.SkipWeekends}
+
+	switch strings.ToLower(file.Handoff) {
+	case "weekly", "":
+		rotation.weekly = true
+	case "daily":
+	default:
+		return OnCallRotation{}, fmt.Errorf("unknown handoff %q, expected weekly or daily", file.Handoff)
+	}
+
+	first, err := time.Parse(time.DateOnly, file.Start)
+	if err != nil {
+		return OnCallRotation{}, fmt.Errorf("invalid start: %v", err)
+	}
+	rotation.first = first
+	if file.HandoffDay != "" {
+		day, ok := parseWeekday(file.HandoffDay)
+		if !ok {
+			return OnCallRotation{}, fmt.Errorf("invalid handoff_day %q", file.HandoffDay)
+		}
+		if rotation.weekly && day != first.Weekday() {
+			return OnCallRotation{}, fmt.Errorf("start %s is a %s, not the handoff_day %s", file.Start, first.Weekday(), day)
+		}
+	}
+	if !rotation.weekly && rotation.skipWeekends && isWeekend(first) {
+		return OnCallRotation{}, fmt.Errorf("start %s is on a weekend", file.Start)
+	}
+
+	handoffTime := file.HandoffTime
+	if handoffTime == "" {
+		handoffTime = "09:00"
+	}
+	handoff, err := time.Parse("15:04", handoffTime)
+	if err != nil {
+		return OnCallRotation{}, fmt.Errorf("invalid handoff_time: %v", err)
+	}
+	rotation.handoffHour, rotation.handoffMin = handoff.Hour(), handoff.Minute()
+
+	if file.TimeZone != "" {
+		rotation.location, err = loadLocation(file.TimeZone)
+		if err != nil {
+			return OnCallRotation{}, fmt.Errorf("invalid time_zone: %v", err)
+		}
+	}
+
+	for _, skip := range file.Skip {
+		absence, err := newListedHoliday(skip.From, skip.To, skip.Member)
+		if err != nil {
+			return OnCallRotation{}, fmt.Errorf("invalid skip: %v", err)
+		}
+		rotation.unavailable = append(rotation.unavailable, rotationAbsence{member: absence.name, first: absence.first, last: absence.last})
+	}
+	for _, override := range file.Overrides {
+		if strings.TrimSpace(override.Member) == "" {
+			return OnCallRotation{}, fmt.Errorf("override starting %s has no member", override.Start)
+		}
+		for _, value := range []string{override.Start, override.End} {
+			if _, err := time.Parse(rotationOverrideLayout, value); err != nil {
+				return OnCallRotation{}, fmt.Errorf("invalid override of %s: %v", override.Member, err)
+			}
+		}
+		rotation.overrides = append(rotation.overrides, rotationOverride{member: override.Member, start: override.Start, end: override.End})
+	}
+	return rotation, nil
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(name, day.String()) || strings.EqualFold(name, day.String()[:3]) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+func (r OnCallRotation) GetEvents(asOf time.Time) ([]string, error) {
+	log.Printf("Get event of : %s, from on-call rotation : %s", asOf.Format(time.DateOnly), r.source)
+	start, end := dayWindow(asOf)
+	events, err := r.eventsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return eventSummaries(events), nil
+}
+
+func (r OnCallRotation) GetEventsBetween(start, end time.Time) ([]string, error) {
+	log.Printf("Get event between : %s and %s, from on-call rotation : %s", start.Format(time.RFC3339), end.Format(time.RFC3339), r.source)
+	events, err := r.eventsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return datedEventSummaries(events, start.Location()), nil
+}
+
+func (r OnCallRotation) ListEvents(start, end time.Time) ([]service.Event, error) {
+	log.Printf("List events between : %s and %s, from on-call rotation : %s", start.Format(time.RFC3339), end.Format(time.RFC3339), r.source)
+	events, err := r.eventsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return serviceEvents(events, start.Location()), nil
+}
+
+func (r OnCallRotation) eventsBetween(start, end time.Time) ([]calendarEvent, error) {
+	loc := r.location
+	if loc == nil {
+		loc = start.Location()
+	}
+	var events []calendarEvent
+	shiftStart := r.handoffOn(r.first, loc)
+	for n := 0; shiftStart.Before(end); n++ {
+		shiftEnd := r.nextHandoff(shiftStart, loc)
+		if shiftEnd.After(start) {
+			if member, ok := r.assignee(n, shiftStart, shiftEnd, loc); ok {
+				events = append(events, calendarEvent{summary: member, start: shiftStart, end: shiftEnd})
+			}
+		}
+		shiftStart = shiftEnd
+	}
+
+	for _, override := range r.overrides {
+		overrideStart, _ := time.ParseInLocation(rotationOverrideLayout, override.start, loc)
+		overrideEnd, _ := time.ParseInLocation(rotationOverrideLayout, override.end, loc)
+		events = append(subtractInterval(events, overrideStart, overrideEnd),
+			calendarEvent{summary: override.member, start: overrideStart, end: overrideEnd})
+	}
+
+	for i := range events {
+		events[i].start = events[i].start.In(start.Location())
+		events[i].end = events[i].end.In(start.Location())
+	}
+	return eventsOverlapping(mergeContiguousEvents(events), start, end), nil
+}
+
+func (r OnCallRotation) handoffOn(date time.Time, loc *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), r.handoffHour, r.handoffMin, 0, 0, loc)
+}
+
+func (r OnCallRotation) nextHandoff(handoff time.Time, loc *time.Location) time.Time {
+	if r.weekly {
+		return r.handoffOn(handoff.AddDate(0, 0, 7), loc)
+	}
+	next := handoff.AddDate(0, 0, 1)
+	for r.skipWeekends && isWeekend(next) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return r.handoffOn(next, loc)
+}
+
+// assignee returns the n-th member in rotation order, or the next member after them who is not
+// skipped for the shift. The order itself is not shifted, so later shifts are unaffected.
+func (r OnCallRotation) assignee(n int, shiftStart, shiftEnd time.Time, loc *time.Location) (string, bool) {
+	for k := 0; k < len(r.members); k++ {
+		member := r.members[(n+k)%len(r.members)]
+		if !r.isUnavailable(member, shiftStart, shiftEnd, loc) {
+			return member, true
+		}
+	}
+	return "", false
+}
+
+func (r OnCallRotation) isUnavailable(member string, shiftStart, shiftEnd time.Time, loc *time.Location) bool {
+	for _, absence := range r.unavailable {
+		if absence.member != member {
+			continue
+		}
+		first := time.Date(absence.first.Year(), absence.first.Month(), absence.first.Day(), 0, 0, 0, 0, loc)
+		last := time.Date(absence.last.Year(), absence.last.Month(), absence.last.Day()+1, 0, 0, 0, 0, loc)
+		if first.Before(shiftEnd) && last.After(shiftStart) {
+			return true
+		}
+	}
+	return false
+}
+
+// subtractInterval cuts [start, end) out of every event, splitting events that contain it.
+func subtractInterval(events []calendarEvent, start, end time.Time) []calendarEvent {
+	var remaining []calendarEvent
+	for _, event := range events {
+		if !event.start.Before(end) || !event.end.After(start) {
+			remaining = append(remaining, event)
+			continue
+		}
+		if event.start.Before(start) {
+			before := event
+			before.end = start
+			remaining = append(remaining, before)
+		}
+		if event.end.After(end) {
+			after := event
+			after.start = end
+			remaining = append(remaining, after)
+		}
+	}
+	return remaining
+}
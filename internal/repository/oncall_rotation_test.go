@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testWeeklyRotation = `
+members: [Alice, Bob, Carol]
+handoff: weekly
+handoff_day: monday
+handoff_time: "10:00"
+start: 2025-01-06
+time_zone: Asia/Bangkok
+skip:
+  - member: Bob
+    from: 2025-02-03
+    to: 2025-02-05
+overrides:
+  - member: Carol
+    start: 2025-03-15 00:00
+    end: 2025-03-16 00:00
+`
+
+func newTestRotation(t *testing.T, config string) OnCallRotation {
+	path := filepath.Join(t.TempDir(), "rotation.yaml")
+	os.WriteFile(path, []byte(config), 0o644)
+	rotation, err := NewOnCallRotation(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return rotation
+}
+
+func TestOnCallRotation_GetEventsBetween_CoversSeveralMonths(t *testing.T) {
+	// Arrange
+	repo := newTestRotation(t, testWeeklyRotation)
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	// Act
+	events, err := repo.GetEventsBetween(time.Date(2025, 1, 1, 0, 0, 0, 0, bangkok), time.Date(2025, 4, 1, 0, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// Bob is skipped in the week of 3 Feb, so Carol covers it and her own week after.
+	// Carol's override on 15 Mar splits Alice's week.
+	expected := []string{
+		"2025-01-06: Alice", "2025-01-13: Bob", "2025-01-20: Carol", "2025-01-27: Alice",
+		"2025-02-03: Carol", "2025-02-17: Alice", "2025-02-24: Bob",
+		"2025-03-03: Carol", "2025-03-10: Alice", "2025-03-15: Carol", "2025-03-16: Alice",
+		"2025-03-17: Bob", "2025-03-24: Carol", "2025-03-31: Alice",
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Expected %v, got %v", expected, events)
+	}
+}
+
+func TestOnCallRotation_GetEvents_HandoffDay(t *testing.T) {
+	// Arrange
+	repo := newTestRotation(t, testWeeklyRotation)
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	for _, tc := range []struct {
+		asOf     time.Time
+		expected []string
+	}{
+		{time.Date(2025, 3, 17, 8, 0, 0, 0, bangkok), []string{"Alice", "Bob"}},
+		{time.Date(2025, 3, 18, 8, 0, 0, 0, bangkok), []string{"Bob"}},
+		// 21 weeks after the start the rotation is back at Alice.
+		{time.Date(2025, 6, 2, 8, 0, 0, 0, bangkok), []string{"Carol", "Alice"}},
+	} {
+		// Act
+		events, err := repo.GetEvents(tc.asOf)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !reflect.DeepEqual(events, tc.expected) {
+			t.Errorf("Expected %v on %s, got %v", tc.expected, tc.asOf.Format(time.DateOnly), events)
+		}
+	}
+}
+
+func TestOnCallRotation_GetEvents_DailySkippingWeekends(t *testing.T) {
+	// Arrange
+	repo := newTestRotation(t, `
+members: [A, B]
+handoff: daily
+start: 2025-08-11
+skip_weekends: true
+`)
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	// Act
+	saturday, err := repo.GetEvents(time.Date(2025, 8, 16, 8, 0, 0, 0, bangkok))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	monday, err := repo.GetEvents(time.Date(2025, 8, 18, 8, 0, 0, 0, bangkok))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Assert
+	// Friday's shift runs until Monday 09:00, when the daily window starts.
+	if !reflect.DeepEqual(saturday, []string{"A"}) {
+		t.Errorf("Expected [A] on Saturday, got %v", saturday)
+	}
+	if !reflect.DeepEqual(monday, []string{"B"}) {
+		t.Errorf("Expected [B] on Monday, got %v", monday)
+	}
+}
+
+func TestNewOnCallRotation_RejectsStartOffHandoffDay(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "rotation.yaml")
+	os.WriteFile(path, []byte("members: [A]\nhandoff_day: tuesday\nstart: 2025-01-06\n"), 0o644)
+
+	// Act
+	_, err := NewOnCallRotation(path)
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "not the handoff_day Tuesday") {
+		t.Errorf("Expected handoff_day error, got %v", err)
+	}
+}
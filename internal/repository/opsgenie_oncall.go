@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitbub.com/tsongpon/iris/internal/service"
+)
+
+const defaultOpsgenieURL = "https://api.opsgenie.com"
+
+type OpsgenieConfig struct {
+	APIKey string
+	// Schedule is the schedule id, or its name when ScheduleIsName is set.
+	Schedule       string
+	ScheduleIsName bool
+	// APIURL overrides the Opsgenie API endpoint, e.g. https://api.eu.opsgenie.com.
+	APIURL string
+}
+
+// OpsgenieOnCall reads who is on call from the final timeline of an Opsgenie schedule, which
+// combines the schedule's rotations with its overrides.
+type OpsgenieOnCall struct {
+	config     OpsgenieConfig
+	people     PeopleDirectory
+	httpClient *http.Client
+}
+
+// NewOpsgenieOnCall creates the on-call source; people maps Opsgenie users to the names used in notifications.
+func NewOpsgenieOnCall(config OpsgenieConfig, people PeopleDirectory) OpsgenieOnCall {
+	if config.APIURL == "" {
+		config.APIURL = defaultOpsgenieURL
+	}
+	return OpsgenieOnCall{config: config, people: people, httpClient: &http.Client{Timeout: 30 * time.Second}}
+}
+
+type opsgenieTimelineResponse struct {
+	Data struct {
+		FinalTimeline struct {
+			Rotations []struct {
+				Name    string `json:"name"`
+				Periods []struct {
+					StartDate time.Time `json:"startDate"`
+					EndDate   time.Time `json:"endDate"`
+					Type      string    `json:"type"`
+					Recipient struct {
+						ID   string `json:"id"`
+						Type string `json:"type"`
+						Name string `json:"name"`
+					} `json:"recipient"`
+				} `json:"periods"`
+			} `json:"rotations"`
+		} `json:"finalTimeline"`
+	} `json:"data"`
+}
+
+type opsgenieError struct {
+	Message string `json:"message"`
+}
+
+func (o OpsgenieOnCall) GetEvents(asOf time.Time) ([]string, error) {
+	log.Printf("Get event of : %s, from opsgenie schedule : %s", asOf.Format(time.DateOnly), o.config.Schedule)
+	start, end := dayWindow(asOf)
+	events, err := o.eventsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return eventSummaries(events), nil
+}
+
+func (o OpsgenieOnCall) GetEventsBetween(start, end time.Time) ([]string, error) {
+	log.Printf("Get event between : %s and %s, from opsgenie schedule : %s", start.Format(time.RFC3339), end.Format(time.RFC3339), o.config.Schedule)
+	events, err := o.eventsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return datedEventSummaries(events, start.Location()), nil
+}
+
+func (o OpsgenieOnCall) ListEvents(start, end time.Time) ([]service.Event, error) {
+	log.Printf("List events between : %s and %s, from opsgenie schedule : %s", start.Format(time.RFC3339), end.Format(time.RFC3339), o.config.Schedule)
+	events, err := o.eventsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return serviceEvents(events, start.Location()), nil
+}
+
+func (o OpsgenieOnCall) eventsBetween(start, end time.Time) ([]calendarEvent, error) {
+	timeline, err := o.timeline(start, end)
+	if err != nil {
+		return nil, err
+	}
+	loc := start.Location()
+	var events []calendarEvent
+	for _, rotation := range timeline.Data.FinalTimeline.Rotations {
+		for _, period := range rotation.Periods {
+			// Periods without a recipient are gaps in the rotation.
+			if period.Recipient.Type == "none" || (period.Recipient.ID == "" && period.Recipient.Name == "") {
+				continue
+			}
+			events = append(events, calendarEvent{
+				summary: o.people.displayName(period.Recipient.Name, period.Recipient.ID),
+				start:   period.StartDate.In(loc),
+				end:     period.EndDate.In(loc),
+			})
+		}
+	}
+	return eventsOverlapping(mergeContiguousEvents(events), start, end), nil
+}
+
+// timeline requests whole days starting at start's midnight, enough to cover end.
+func (o OpsgenieOnCall) timeline(start, end time.Time) (opsgenieTimelineResponse, error) {
+	from := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	days := int(math.Ceil(end.Sub(from).Hours() / 24))
+	if days < 1 {
+		days = 1
+	}
+	identifierType := "id"
+	if o.config.ScheduleIsName {
+		identifierType = "name"
+	}
+	query := url.Values{}
+	query.Set("identifierType", identifierType)
+	query.Set("date", from.Format(time.RFC3339))
+	query.Set("interval", strconv.Itoa(days))
+	query.Set("intervalUnit", "days")
+	timelineURL := fmt.Sprintf("%s/v2/schedules/%s/timeline?%s", o.config.APIURL, url.PathEscape(o.config.Schedule), query.Encode())
+
+	req, err := http.NewRequest(http.MethodGet, timelineURL, nil)
+	if err != nil {
+		return opsgenieTimelineResponse{}, fmt.Errorf("failed to create opsgenie request: %v", err)
+	}
+	req.Header.Set("Authorization", "GenieKey "+o.config.APIKey)
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return opsgenieTimelineResponse{}, fmt.Errorf("failed to call opsgenie schedule timeline: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return opsgenieTimelineResponse{}, fmt.Errorf("failed to read opsgenie response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var ogErr opsgenieError
+		if json.Unmarshal(body, &ogErr) == nil && ogErr.Message != "" {
+			return opsgenieTimelineResponse{}, fmt.Errorf("opsgenie schedule timeline returned status %d: %s", resp.StatusCode, ogErr.Message)
+		}
+		return opsgenieTimelineResponse{}, fmt.Errorf("opsgenie schedule timeline returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	var timeline opsgenieTimelineResponse
+	if err := json.Unmarshal(body, &timeline); err != nil {
+		return opsgenieTimelineResponse{}, fmt.Errorf("failed to decode opsgenie response: %v", err)
+	}
+	return timeline, nil
+}
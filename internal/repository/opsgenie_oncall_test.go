@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newFakeOpsgenieServer(t *testing.T) (*httptest.Server, *[]string) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "GenieKey og-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message":"Could not authenticate","took":0.0,"requestId":"1"}`))
+			return
+		}
+		if r.URL.Path != "/v2/schedules/Platform On-Call/timeline" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Schedule with identifier [missing] could not be found","took":0.0,"requestId":"2"}`))
+			return
+		}
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"finalTimeline":{"rotations":[
+			{"name":"weekly","periods":[
+				{"startDate":"2025-08-11T02:00:00Z","endDate":"2025-08-13T02:00:00Z","type":"default",
+				 "recipient":{"id":"og-1","type":"user","name":"somchai@example.com"}},
+				{"startDate":"2025-08-13T02:00:00Z","endDate":"2025-08-14T02:00:00Z","type":"override",
+				 "recipient":{"id":"og-2","type":"user","name":"jane@example.com"}},
+				{"startDate":"2025-08-14T02:00:00Z","endDate":"2025-08-15T02:00:00Z","type":"default",
+				 "recipient":{"type":"none"}},
+				{"startDate":"2025-08-15T02:00:00Z","endDate":"2025-08-18T02:00:00Z","type":"default",
+				 "recipient":{"id":"og-1","type":"user","name":"somchai@example.com"}}
+			]}
+		]}},"took":0.1,"requestId":"3"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &queries
+}
+
+func TestOpsgenieOnCall_GetEventsBetween_ReadsFinalTimeline(t *testing.T) {
+	// Arrange
+	server, queries := newFakeOpsgenieServer(t)
+	repo := NewOpsgenieOnCall(OpsgenieConfig{APIKey: "og-key", Schedule: "Platform On-Call", ScheduleIsName: true, APIURL: server.URL}, newTestPeopleDirectory(t))
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	// Act
+	events, err := repo.GetEventsBetween(time.Date(2025, 8, 11, 0, 0, 0, 0, bangkok), time.Date(2025, 8, 18, 0, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []string{"2025-08-11: พี่ชาย", "2025-08-13: เจน", "2025-08-15: พี่ชาย"}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Expected %v, got %v", expected, events)
+	}
+	if len(*queries) != 1 || !strings.Contains((*queries)[0], "date=2025-08-11T00%3A00%3A00%2B07%3A00") ||
+		!strings.Contains((*queries)[0], "interval=7") || !strings.Contains((*queries)[0], "identifierType=name") {
+		t.Errorf("Expected a 7 day timeline from Bangkok midnight, got %v", *queries)
+	}
+}
+
+func TestOpsgenieOnCall_GetEvents_SkipsUnassignedPeriods(t *testing.T) {
+	// Arrange
+	server, _ := newFakeOpsgenieServer(t)
+	repo := NewOpsgenieOnCall(OpsgenieConfig{APIKey: "og-key", Schedule: "Platform On-Call", APIURL: server.URL}, newTestPeopleDirectory(t))
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	// Act
+	events, err := repo.GetEvents(time.Date(2025, 8, 14, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// Jane hands over at 09:00 and nobody is assigned for the rest of the day.
+	if len(events) != 0 {
+		t.Errorf("Expected no events, got %v", events)
+	}
+}
+
+func TestOpsgenieOnCall_GetEvents_APIError(t *testing.T) {
+	// Arrange
+	server, _ := newFakeOpsgenieServer(t)
+	repo := NewOpsgenieOnCall(OpsgenieConfig{APIKey: "og-key", Schedule: "missing", APIURL: server.URL}, PeopleDirectory{})
+
+	// Act
+	_, err := repo.GetEvents(time.Date(2025, 8, 13, 8, 0, 0, 0, time.UTC))
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "could not be found") {
+		t.Errorf("Expected Opsgenie error message, got %v", err)
+	}
+}
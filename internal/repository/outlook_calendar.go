@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"gitbub.com/tsongpon/iris/internal/service"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	defaultGraphURL       = "https://graph.microsoft.com/v1.0"
+	defaultMicrosoftLogin = "https://login.microsoftonline.com"
+	graphDateTimeLayout   = "2006-01-02T15:04:05.9999999"
+)
+
+type OutlookConfig struct {
+	TenantID     string
+	ClientID     string
+	ClientSecret string
+	// UserID is the id or user principal name of the mailbox that owns the calendar.
+	UserID string
+	// CalendarID selects a secondary or shared calendar; the mailbox's default calendar is used when empty.
+	CalendarID string
+	// GraphURL and TokenURL override the Microsoft endpoints, e.g. for national clouds.
+	GraphURL string
+	TokenURL string
+}
+
+// OutlookCalendar reads events from a Microsoft 365 calendar through the Microsoft Graph calendarView API
+// using application (client credentials) permissions.
+type OutlookCalendar struct {
+	config     OutlookConfig
+	httpClient *http.Client
+}
+
+func NewOutlookCalendar(config OutlookConfig) OutlookCalendar {
+	if config.GraphURL == "" {
+		config.GraphURL = defaultGraphURL
+	}
+	if config.TokenURL == "" {
+		config.TokenURL = fmt.Sprintf("%s/%s/oauth2/v2.0/token", defaultMicrosoftLogin, config.TenantID)
+	}
+	credentials := clientcredentials.Config{
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+		TokenURL:     config.TokenURL,
+		Scopes:       []string{"https://graph.microsoft.com/.default"},
+	}
+	client := credentials.Client(context.Background())
+	client.Timeout = 30 * time.Second
+	return OutlookCalendar{config: config, httpClient: client}
+}
+
+type graphEventPage struct {
+	Value    []graphEvent `json:"value"`
+	NextLink string       `json:"@odata.nextLink"`
+}
+
+type graphEvent struct {
+	Subject     string            `json:"subject"`
+	IsAllDay    bool              `json:"isAllDay"`
+	IsCancelled bool              `json:"isCancelled"`
+	Start       graphDateTimeZone `json:"start"`
+	End         graphDateTimeZone `json:"end"`
+}
+
+type graphDateTimeZone struct {
+	DateTime string `json:"dateTime"`
+	TimeZone string `json:"timeZone"`
+}
+
+type graphError struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (o OutlookCalendar) GetEvents(asOf time.Time) ([]string, error) {
+	log.Printf("Get event of : %s, from outlook calendar of : %s", asOf.Format(time.DateOnly), o.config.UserID)
+	start, end := dayWindow(asOf)
+	events, err := o.eventsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return eventSummaries(events), nil
+}
+
+func (o OutlookCalendar) GetEventsBetween(start, end time.Time) ([]string, error) {
+	log.Printf("Get event between : %s and %s, from outlook calendar of : %s", start.Format(time.RFC3339), end.Format(time.RFC3339), o.config.UserID)
+	events, err := o.eventsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return datedEventSummaries(events, start.Location()), nil
+}
+
+func (o OutlookCalendar) ListEvents(start, end time.Time) ([]service.Event, error) {
+	log.Printf("List events between : %s and %s, from outlook calendar of : %s", start.Format(time.RFC3339), end.Format(time.RFC3339), o.config.UserID)
+	events, err := o.eventsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return serviceEvents(events, start.Location()), nil
+}
+
+func (o OutlookCalendar) eventsBetween(start, end time.Time) ([]calendarEvent, error) {
+	loc := start.Location()
+	next := o.calendarViewURL(start, end)
+	var events []calendarEvent
+	for next != "" {
+		page, err := o.getPage(next)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Value {
+			if item.IsCancelled {
+				continue
+			}
+			event, err := convertGraphEvent(item, loc)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, event)
+		}
+		next = page.NextLink
+	}
+	return eventsOverlapping(events, start, end), nil
+}
+
+func (o OutlookCalendar) calendarViewURL(start, end time.Time) string {
+	path := fmt.Sprintf("%s/users/%s/calendar/calendarView", o.config.GraphURL, url.PathEscape(o.config.UserID))
+	if o.config.CalendarID != "" {
+		path = fmt.Sprintf("%s/users/%s/calendars/%s/calendarView", o.config.GraphURL, url.PathEscape(o.config.UserID), url.PathEscape(o.config.CalendarID))
+	}
+	query := url.Values{}
+	query.Set("startDateTime", start.UTC().Format(time.RFC3339))
+	query.Set("endDateTime", end.UTC().Format(time.RFC3339))
+	query.Set("$select", "subject,isAllDay,isCancelled,start,end")
+	query.Set("$orderby", "start/dateTime")
+	query.Set("$top", "100")
+	return path + "?" + query.Encode()
+}
+
+func (o OutlookCalendar) getPage(pageURL string) (graphEventPage, error) {
+	req, err := http.NewRequest(http.MethodGet, pageURL, nil)
+	if err != nil {
+		return graphEventPage{}, fmt.Errorf("failed to create graph request: %v", err)
+	}
+	req.Header.Set("Prefer", `outlook.timezone="UTC"`)
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return graphEventPage{}, fmt.Errorf("failed to call graph calendarView: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return graphEventPage{}, fmt.Errorf("failed to read graph response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var graphErr graphError
+		if json.Unmarshal(body, &graphErr) == nil && graphErr.Error.Code != "" {
+			return graphEventPage{}, fmt.Errorf("graph calendarView returned %s: %s", graphErr.Error.Code, graphErr.Error.Message)
+		}
+		return graphEventPage{}, fmt.Errorf("graph calendarView returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	var page graphEventPage
+	if err := json.Unmarshal(body, &page); err != nil {
+		return graphEventPage{}, fmt.Errorf("failed to decode graph response: %v", err)
+	}
+	return page, nil
+}
+
+// convertGraphEvent reads Graph's zone-less dateTime in the zone it is reported in. All-day events
+// cover whole dates, so they are anchored to midnight in loc like iCalendar all-day events.
+func convertGraphEvent(item graphEvent, loc *time.Location) (calendarEvent, error) {
+	start, err := parseGraphDateTime(item.Start, loc, item.IsAllDay)
+	if err != nil {
+		return calendarEvent{}, fmt.Errorf("invalid start of event %q: %v", item.Subject, err)
+	}
+	end, err := parseGraphDateTime(item.End, loc, item.IsAllDay)
+	if err != nil {
+		return calendarEvent{}, fmt.Errorf("invalid end of event %q: %v", item.Subject, err)
+	}
+	return calendarEvent{summary: item.Subject, start: start, end: end, allDay: item.IsAllDay}, nil
+}
+
+func parseGraphDateTime(value graphDateTimeZone, loc *time.Location, allDay bool) (time.Time, error) {
+	if allDay {
+		date, err := time.Parse(graphDateTimeLayout, value.DateTime)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc), nil
+	}
+	zone, err := loadLocation(value.TimeZone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.ParseInLocation(graphDateTimeLayout, value.DateTime, zone)
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newFakeGraphServer serves the token endpoint and a calendarView split over two pages.
+func newFakeGraphServer(t *testing.T) (*httptest.Server, *[]string) {
+	var calendarViewQueries []string
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/tenant/oauth2/v2.0/token":
+			r.ParseForm()
+			if r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("scope") != "https://graph.microsoft.com/.default" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"access_token":"graph-token","token_type":"Bearer","expires_in":3600}`))
+		case r.Header.Get("Authorization") != "Bearer graph-token":
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":{"code":"InvalidAuthenticationToken","message":"Access token is empty."}}`))
+		case r.URL.Path == "/v1.0/users/leave@example.com/calendars/shared-leave/calendarView":
+			calendarViewQueries = append(calendarViewQueries, r.URL.RawQuery)
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Query().Get("$skip") == "" {
+				fmt.Fprintf(w, `{"value":[
+					{"subject":"John Doe","isAllDay":true,"isCancelled":false,
+					 "start":{"dateTime":"2025-08-12T00:00:00.0000000","timeZone":"UTC"},
+					 "end":{"dateTime":"2025-08-14T00:00:00.0000000","timeZone":"UTC"}},
+					{"subject":"Cancelled leave","isAllDay":true,"isCancelled":true,
+					 "start":{"dateTime":"2025-08-12T00:00:00.0000000","timeZone":"UTC"},
+					 "end":{"dateTime":"2025-08-13T00:00:00.0000000","timeZone":"UTC"}}
+				],"@odata.nextLink":"%s/v1.0/users/leave@example.com/calendars/shared-leave/calendarView?$skip=2"}`, server.URL)
+				return
+			}
+			w.Write([]byte(`{"value":[
+				{"subject":"Jane Doe (afternoon)","isAllDay":false,"isCancelled":false,
+				 "start":{"dateTime":"2025-08-12T13:00:00.0000000","timeZone":"SE Asia Standard Time"},
+				 "end":{"dateTime":"2025-08-12T18:00:00.0000000","timeZone":"SE Asia Standard Time"}},
+				{"subject":"Night shift handover","isAllDay":false,"isCancelled":false,
+				 "start":{"dateTime":"2025-08-11T23:00:00.0000000","timeZone":"UTC"},
+				 "end":{"dateTime":"2025-08-12T01:00:00.0000000","timeZone":"UTC"}}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"code":"ErrorItemNotFound","message":"The specified object was not found in the store."}}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &calendarViewQueries
+}
+
+func newTestOutlookCalendar(server *httptest.Server, calendarID string) OutlookCalendar {
+	return NewOutlookCalendar(OutlookConfig{
+		TenantID:     "tenant",
+		ClientID:     "client",
+		ClientSecret: "secret",
+		UserID:       "leave@example.com",
+		CalendarID:   calendarID,
+		GraphURL:     server.URL + "/v1.0",
+		TokenURL:     server.URL + "/tenant/oauth2/v2.0/token",
+	})
+}
+
+func TestOutlookCalendar_GetEvents_FollowsNextLinkAndConvertsTimeZones(t *testing.T) {
+	// Arrange
+	server, queries := newFakeGraphServer(t)
+	repo := newTestOutlookCalendar(server, "shared-leave")
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	// Act
+	events, err := repo.GetEvents(time.Date(2025, 8, 12, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// The handover ends at 08:00 Bangkok time, before the daily window starts.
+	expected := []string{"John Doe", "Jane Doe (afternoon)"}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Expected %v, got %v", expected, events)
+	}
+	if len(*queries) != 2 {
+		t.Fatalf("Expected 2 calendarView pages, got %d", len(*queries))
+	}
+	if !strings.Contains((*queries)[0], "startDateTime=2025-08-12T02%3A00%3A00Z") {
+		t.Errorf("Expected window start in UTC, got %s", (*queries)[0])
+	}
+}
+
+func TestOutlookCalendar_GetEventsBetween(t *testing.T) {
+	// Arrange
+	server, _ := newFakeGraphServer(t)
+	repo := newTestOutlookCalendar(server, "shared-leave")
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	// Act
+	events, err := repo.GetEventsBetween(time.Date(2025, 8, 1, 0, 0, 0, 0, bangkok), time.Date(2025, 8, 31, 0, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []string{"2025-08-12: John Doe", "2025-08-12: Night shift handover", "2025-08-12: Jane Doe (afternoon)"}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Expected %v, got %v", expected, events)
+	}
+}
+
+func TestOutlookCalendar_GetEvents_GraphError(t *testing.T) {
+	// Arrange
+	server, _ := newFakeGraphServer(t)
+	repo := newTestOutlookCalendar(server, "missing")
+
+	// Act
+	_, err := repo.GetEvents(time.Date(2025, 8, 12, 8, 0, 0, 0, time.UTC))
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "ErrorItemNotFound") {
+		t.Errorf("Expected ErrorItemNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitbub.com/tsongpon/iris/internal/service"
+)
+
+const (
+	defaultPagerDutyURL = "https://api.pagerduty.com"
+	pagerDutyPageSize   = 100
+)
+
+type PagerDutyConfig struct {
+	APIToken   string
+	ScheduleID string
+	// APIURL overrides the PagerDuty REST API endpoint, e.g. for the EU service region.
+	APIURL string
+}
+
+// PagerDutyOnCall reads who is on call from a PagerDuty schedule. PagerDuty renders the schedule
+// layers and overrides into on-call entries, so the result is the final schedule as responders see it.
+type PagerDutyOnCall struct {
+	config     PagerDutyConfig
+	people     PeopleDirectory
+	httpClient *http.Client
+}
+
+// NewPagerDutyOnCall creates the on-call source; people maps PagerDuty users to the names used in notifications.
+func NewPagerDutyOnCall(config PagerDutyConfig, people PeopleDirectory) PagerDutyOnCall {
+	if config.APIURL == "" {
+		config.APIURL = defaultPagerDutyURL
+	}
+	return PagerDutyOnCall{config: config, people: people, httpClient: &http.Client{Timeout: 30 * time.Second}}
+}
+
+type pagerDutyOnCallPage struct {
+	OnCalls []pagerDutyOnCall `json:"oncalls"`
+	More    bool              `json:"more"`
+}
+
+type pagerDutyOnCall struct {
+	User struct {
+		ID      string `json:"id"`
+		Summary string `json:"summary"`
+		Name    string `json:"name"`
+		Email   string `json:"email"`
+	} `json:"user"`
+	// Start and End are null for entries that are not bounded by a schedule.
+	Start *time.Time `json:"start"`
+	End   *time.Time `json:"end"`
+}
+
+type pagerDutyError struct {
+	Error struct {
+		Code    int      `json:"code"`
+		Message string   `json:"message"`
+		Errors  []string `json:"errors"`
+	} `json:"error"`
+}
+
+func (p PagerDutyOnCall) GetEvents(asOf time.Time) ([]string, error) {
+	log.Printf("Get event of : %s, from pagerduty schedule : %s", asOf.Format(time.DateOnly), p.config.ScheduleID)
+	start, end := dayWindow(asOf)
+	events, err := p.eventsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return eventSummaries(events), nil
+}
+
+func (p PagerDutyOnCall) GetEventsBetween(start, end time.Time) ([]string, error) {
+	log.Printf("Get event between : %s and %s, from pagerduty schedule : %s", start.Format(time.RFC3339), end.Format(time.RFC3339), p.config.ScheduleID)
+	events, err := p.eventsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return datedEventSummaries(events, start.Location()), nil
+}
+
+func (p PagerDutyOnCall) ListEvents(start, end time.Time) ([]service.Event, error) {
+	log.Printf("List events between : %s and %s, from pagerduty schedule : %s", start.Format(time.RFC3339), end.Format(time.RFC3339), p.config.ScheduleID)
+	events, err := p.eventsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return serviceEvents(events, start.Location()), nil
+}
+
+func (p PagerDutyOnCall) eventsBetween(start, end time.Time) ([]calendarEvent, error) {
+	loc := start.Location()
+	var events []calendarEvent
+	for offset, more := 0, true; more; offset += pagerDutyPageSize {
+		page, err := p.getPage(start, end, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, onCall := range page.OnCalls {
+			event := calendarEvent{
+				summary: p.people.displayName(onCall.User.Summary, onCall.User.Name, onCall.User.Email, onCall.User.ID),
+				start:   start,
+				end:     end,
+			}
+			if onCall.Start != nil {
+				event.start = onCall.Start.In(loc)
+			}
+			if onCall.End != nil {
+				event.end = onCall.End.In(loc)
+			}
+			events = append(events, event)
+		}
+		more = page.More
+	}
+	// A schedule used on several escalation levels or policies yields the same shift more than once.
+	return eventsOverlapping(mergeContiguousEvents(events), start, end), nil
+}
+
+func (p PagerDutyOnCall) getPage(start, end time.Time, offset int) (pagerDutyOnCallPage, error) {
+	query := url.Values{}
+	query.Set("schedule_ids[]", p.config.ScheduleID)
+	query.Set("include[]", "users")
+	query.Set("since", start.Format(time.RFC3339))
+	query.Set("until", end.Format(time.RFC3339))
+	if zone := start.Location().String(); zone != "Local" {
+		query.Set("time_zone", zone)
+	}
+	query.Set("limit", strconv.Itoa(pagerDutyPageSize))
+	query.Set("offset", strconv.Itoa(offset))
+
+	req, err := http.NewRequest(http.MethodGet, p.config.APIURL+"/oncalls?"+query.Encode(), nil)
+	if err != nil {
+		return pagerDutyOnCallPage{}, fmt.Errorf("failed to create pagerduty request: %v", err)
+	}
+	req.Header.Set("Accept", "application/vnd.pagerduty+json;version=2")
+	req.Header.Set("Authorization", "Token token="+p.config.APIToken)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return pagerDutyOnCallPage{}, fmt.Errorf("failed to call pagerduty oncalls: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return pagerDutyOnCallPage{}, fmt.Errorf("failed to read pagerduty response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var pdErr pagerDutyError
+		if json.Unmarshal(body, &pdErr) == nil && pdErr.Error.Message != "" {
+			message := pdErr.Error.Message
+			if len(pdErr.Error.Errors) > 0 {
+				message += ": " + strings.Join(pdErr.Error.Errors, ", ")
+			}
+			return pagerDutyOnCallPage{}, fmt.Errorf("pagerduty oncalls returned status %d: %s", resp.StatusCode, message)
+		}
+		return pagerDutyOnCallPage{}, fmt.Errorf("pagerduty oncalls returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	var page pagerDutyOnCallPage
+	if err := json.Unmarshal(body, &page); err != nil {
+		return pagerDutyOnCallPage{}, fmt.Errorf("failed to decode pagerduty response: %v", err)
+	}
+	return page, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newFakePagerDutyServer serves /oncalls for schedule PSCHED over two pages. The first page repeats
+// the weekly shift for a second escalation level, like PagerDuty does.
+func newFakePagerDutyServer(t *testing.T) (*httptest.Server, *[]string) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Token token=pd-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":{"code":2006,"message":"Invalid Credentials","errors":[]}}`))
+			return
+		}
+		if r.URL.Path != "/oncalls" || r.URL.Query().Get("schedule_ids[]") != "PSCHED" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":{"code":2001,"message":"Invalid Input Provided","errors":["Schedule not found."]}}`))
+			return
+		}
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			fmt.Fprint(w, `{"oncalls":[
+				{"user":{"id":"PUSER1","summary":"Somchai Jaidee","email":"somchai@example.com"},"escalation_level":1,
+				 "start":"2025-08-11T09:00:00+07:00","end":"2025-08-13T09:00:00+07:00"},
+				{"user":{"id":"PUSER1","summary":"Somchai Jaidee","email":"somchai@example.com"},"escalation_level":2,
+				 "start":"2025-08-11T09:00:00+07:00","end":"2025-08-13T09:00:00+07:00"}
+			],"limit":100,"offset":0,"more":true}`)
+		default:
+			fmt.Fprint(w, `{"oncalls":[
+				{"user":{"id":"PUSER2","summary":"Jane Override","email":"jane@example.com"},"escalation_level":1,
+				 "start":"2025-08-13T02:00:00Z","end":"2025-08-13T11:00:00Z"},
+				{"user":{"id":"PUSER1","summary":"Somchai Jaidee","email":"somchai@example.com"},"escalation_level":1,
+				 "start":"2025-08-13T18:00:00+07:00","end":"2025-08-18T09:00:00+07:00"}
+			],"limit":100,"offset":100,"more":false}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &queries
+}
+
+func newTestPeopleDirectory(t *testing.T) PeopleDirectory {
+	people, err := newPeopleDirectory([]directoryPerson{
+		{Name: "พี่ชาย", Email: "Somchai@example.com", PagerDutyID: "PUSER1"},
+		{Name: "เจน", Email: "jane@example.com", Aliases: []string{"Jane Doe"}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return people
+}
+
+func TestPagerDutyOnCall_GetEventsBetween_PaginatesAndMapsPeople(t *testing.T) {
+	// Arrange
+	server, queries := newFakePagerDutyServer(t)
+	repo := NewPagerDutyOnCall(PagerDutyConfig{APIToken: "pd-token", ScheduleID: "PSCHED", APIURL: server.URL}, newTestPeopleDirectory(t))
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	// Act
+	events, err := repo.GetEventsBetween(time.Date(2025, 8, 11, 0, 0, 0, 0, bangkok), time.Date(2025, 8, 18, 0, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// Jane's override splits the week; the duplicate escalation level is listed once.
+	expected := []string{"2025-08-11: พี่ชาย", "2025-08-13: เจน", "2025-08-13: พี่ชาย"}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Expected %v, got %v", expected, events)
+	}
+	if len(*queries) != 2 {
+		t.Fatalf("Expected 2 pages, got %d", len(*queries))
+	}
+	if !strings.Contains((*queries)[0], "time_zone=Asia%2FBangkok") || !strings.Contains((*queries)[1], "offset=100") {
+		t.Errorf("Expected time zone and offset pagination, got %v", *queries)
+	}
+}
+
+func TestPagerDutyOnCall_GetEvents(t *testing.T) {
+	// Arrange
+	server, _ := newFakePagerDutyServer(t)
+	repo := NewPagerDutyOnCall(PagerDutyConfig{APIToken: "pd-token", ScheduleID: "PSCHED", APIURL: server.URL}, PeopleDirectory{})
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	// Act
+	events, err := repo.GetEvents(time.Date(2025, 8, 13, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// Without a directory entry the PagerDuty name is used.
+	expected := []string{"Jane Override", "Somchai Jaidee"}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Expected %v, got %v", expected, events)
+	}
+}
+
+func TestPagerDutyOnCall_GetEvents_APIError(t *testing.T) {
+	// Arrange
+	server, _ := newFakePagerDutyServer(t)
+	repo := NewPagerDutyOnCall(PagerDutyConfig{APIToken: "pd-token", ScheduleID: "MISSING", APIURL: server.URL}, PeopleDirectory{})
+
+	// Act
+	_, err := repo.GetEvents(time.Date(2025, 8, 13, 8, 0, 0, 0, time.UTC))
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "Invalid Input Provided: Schedule not found.") {
+		t.Errorf("Expected PagerDuty error message, got %v", err)
+	}
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"gitbub.com/tsongpon/iris/internal/service"
+	"gopkg.in/yaml.v3"
+)
+
+// PeopleDirectory maps the identities people have in external tools (PagerDuty user IDs, emails,
+// display names) to the name the team knows them by in notifications, and records each person's
+// team and working days for capacity planning.
+type PeopleDirectory struct {
+	people  []directoryPerson
+	byAlias map[string]int
+	// names are the names and aliases FindPerson looks for in free text, in directory order; IDs and
+	// emails only ever match exactly.
+	names []personName
+}
+
+type personName struct {
+	name   string
+	person int
+}
+
+type directoryPerson struct {
+	Name string `yaml:"name"`
+	// Email is matched case-insensitively, as are the aliases.
+	Email   string   `yaml:"email"`
+	Aliases []string `yaml:"aliases"`
+	// PagerDutyID and OpsgenieID are the user IDs in the incident management tools.
+	PagerDutyID string `yaml:"pagerduty_id"`
+	OpsgenieID  string `yaml:"opsgenie_id"`
+	Team        string `yaml:"team"`
+	// WorkingDays lists the weekdays of part-time people, e.g. [mon, wed]; empty means Monday to Friday.
+	WorkingDays []string `yaml:"working_days"`
+	workingDays []time.Weekday
+}
+
+type peopleDirectoryFile struct {
+	People []directoryPerson `yaml:"people"`
+}
+
+// LoadPeopleDirectory reads a YAML people directory. An empty path gives an empty directory,
+// which resolves nobody.
+func LoadPeopleDirectory(path string) (PeopleDirectory, error) {
+	if path == "" {
+		return newPeopleDirectory(nil)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return PeopleDirectory{}, fmt.Errorf("failed to read people directory: %v", err)
+	}
+	var file peopleDirectoryFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return PeopleDirectory{}, fmt.Errorf("invalid people directory %s: %v", path, err)
+	}
+	return newPeopleDirectory(file.People)
+}
+
+func newPeopleDirectory(people []directoryPerson) (PeopleDirectory, error) {
+	directory := PeopleDirectory{people: people, byAlias: map[string]int{}}
+	for i, person := range people {
+		if strings.TrimSpace(person.Name) == "" {
+			return PeopleDirectory{}, fmt.Errorf("person %d in people directory has no name", i+1)
+		}
+		for _, name := range person.WorkingDays {
+			weekday, ok := parseWeekday(name)
+			if !ok {
+				return PeopleDirectory{}, fmt.Errorf("invalid working day %q of %s in people directory", name, person.Name)
+			}
+			people[i].workingDays = append(people[i].workingDays, weekday)
+		}
+		keys := append([]string{person.Name, person.Email, person.PagerDutyID, person.OpsgenieID}, person.Aliases...)
+		for _, key := range keys {
+			key = normalizeAlias(key)
+			if key == "" {
+				continue
+			}
+			if other, exists := directory.byAlias[key]; exists && other != i {
+				return PeopleDirectory{}, fmt.Errorf("%q is used by both %s and %s in people directory", key, people[other].Name, person.Name)
+			}
+			directory.byAlias[key] = i
+		}
+		for _, name := range append([]string{person.Name}, person.Aliases...) {
+			if name = normalizeAlias(name); name != "" {
+				directory.names = append(directory.names, personName{name: name, person: i})
+			}
+		}
+	}
+	return directory, nil
+}
+
+// Resolve returns the directory name of the first identity that matches a person.
+func (d PeopleDirectory) Resolve(identities ...string) (string, bool) {
+	for _, identity := range identities {
+		if i, ok := d.byAlias[normalizeAlias(identity)]; ok {
+			return d.people[i].Name, true
+		}
+	}
+	return "", false
+}
+
+func (d PeopleDirectory) GetPeople() ([]service.Person, error) {
+	var people []service.Person
+	for _, person := range d.people {
+		people = append(people, toServicePerson(person))
+	}
+	return people, nil
+}
+
+// FindPerson matches a calendar event to a person by an exact identity, or else by the longest
+// name or alias the summary contains as a whole word, so "Alice (afternoon)" and "ลาพักร้อน Alice"
+// find Alice but "Alicent" does not. Names of the same length go to the one found first in the
+// summary, then to the one first in the directory.
+func (d PeopleDirectory) FindPerson(eventSummary string) (service.Person, bool) {
+	summary := normalizeAlias(eventSummary)
+	if i, ok := d.byAlias[summary]; ok {
+		return toServicePerson(d.people[i]), true
+	}
+	match, matchLength, matchAt := -1, 0, 0
+	for _, name := range d.names {
+		at := indexWord(summary, name.name)
+		if at < 0 {
+			continue
+		}
+		if len(name.name) > matchLength || (len(name.name) == matchLength && at < matchAt) {
+			match, matchLength, matchAt = name.person, len(name.name), at
+		}
+	}
+	if match < 0 {
+		return service.Person{}, false
+	}
+	return toServicePerson(d.people[match]), true
+}
+
+// indexWord returns the index of the first occurrence of word in s that is not part of a longer
+// word, or -1.
+func indexWord(s, word string) int {
+	for offset := 0; offset < len(s); {
+		i := strings.Index(s[offset:], word)
+		if i < 0 {
+			return -1
+		}
+		start, end := offset+i, offset+i+len(word)
+		first, _ := utf8.DecodeRuneInString(word)
+		last, _ := utf8.DecodeLastRuneInString(word)
+		before, _ := utf8.DecodeLastRuneInString(s[:start])
+		after, _ := utf8.DecodeRuneInString(s[end:])
+		if (start == 0 || wordBoundary(before, first)) && (end == len(s) || wordBoundary(last, after)) {
+			return start
+		}
+		offset = start + 1
+	}
+	return -1
+}
+
+// wordBoundary reports whether adjacent runes a and b belong to different words. Thai is written
+// without spaces, so a Latin name next to Thai text, as in "Aliceลาป่วย", is a word of its own.
+func wordBoundary(a, b rune) bool {
+	if !isWordRune(a) || !isWordRune(b) {
+		return true
+	}
+	return unicode.Is(unicode.Thai, a) != unicode.Is(unicode.Thai, b)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+func toServicePerson(person directoryPerson) service.Person {
+	return service.Person{Name: person.Name, Team: person.Team, WorkingDays: person.workingDays}
+}
+
+// displayName resolves identities through the directory, falling back to the first non-empty
+// identity as reported by the external tool.
+func (d PeopleDirectory) displayName(identities ...string) string {
+	if name, ok := d.Resolve(identities...); ok {
+		return name
+	}
+	for _, identity := range identities {
+		if identity != "" {
+			return identity
+		}
+	}
+	return ""
+}
+
+func normalizeAlias(alias string) string {
+	return strings.ToLower(strings.TrimSpace(alias))
+}
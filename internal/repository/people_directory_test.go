@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadPeopleDirectory_ResolvesAnyIdentity(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "people.yaml")
+	os.WriteFile(path, []byte(`people:
+  - name: พี่ชาย
+    email: somchai@example.com
+    pagerduty_id: PUSER1
+    aliases: ["Somchai J."]
+  - name: เจน
+    opsgenie_id: og-2
+`), 0o644)
+
+	// Act
+	people, err := LoadPeopleDirectory(path)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, identity := range []string{"PUSER1", "SOMCHAI@example.com", " somchai j. "} {
+		if name, ok := people.Resolve(identity); !ok || name != "พี่ชาย" {
+			t.Errorf("Expected %q to resolve to พี่ชาย, got %q", identity, name)
+		}
+	}
+	if name, ok := people.Resolve("unknown", "og-2"); !ok || name != "เจน" {
+		t.Errorf("Expected fallback identity to resolve to เจน, got %q", name)
+	}
+	if _, ok := people.Resolve("unknown"); ok {
+		t.Errorf("Expected unknown identity not to resolve")
+	}
+}
+
+func TestLoadPeopleDirectory_RejectsSharedIdentity(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "people.yaml")
+	os.WriteFile(path, []byte(`people:
+  - name: A
+    email: shared@example.com
+  - name: B
+    aliases: [shared@example.com]
+`), 0o644)
+
+	// Act
+	_, err := LoadPeopleDirectory(path)
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "used by both A and B") {
+		t.Errorf("Expected shared identity error, got %v", err)
+	}
+}
+
+func TestPeopleDirectory_FindPerson_WithTeamAndWorkingDays(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "people.yaml")
+	os.WriteFile(path, []byte(`people:
+  - name: Ann
+    team: backend
+  - name: Anna
+    team: frontend
+    working_days: [mon, wednesday]
+`), 0o644)
+	people, err := LoadPeopleDirectory(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Act
+	ann, annFound := people.FindPerson("Ann (afternoon)")
+	anna, annaFound := people.FindPerson("ลาพักร้อน Anna")
+	_, unknownFound := people.FindPerson("Company offsite")
+
+	// Assert
+	if !annFound || ann.Name != "Ann" || ann.Team != "backend" || len(ann.WorkingDays) != 0 {
+		t.Errorf("Expected Ann of backend working full time, got %+v", ann)
+	}
+	// "Anna" also contains "Ann"; the longer name wins.
+	if !annaFound || anna.Name != "Anna" || !reflect.DeepEqual(anna.WorkingDays, []time.Weekday{time.Monday, time.Wednesday}) {
+		t.Errorf("Expected Anna working Monday and Wednesday, got %+v", anna)
+	}
+	if unknownFound {
+		t.Errorf("Expected no match for an event about nobody")
+	}
+}
+
+func TestPeopleDirectory_FindPerson_MatchesWholeNamesOnly(t *testing.T) {
+	// Arrange
+	people, err := newPeopleDirectory([]directoryPerson{
+		{Name: "Alice", Email: "al@example.com", PagerDutyID: "P1", Aliases: []string{"อลิซ"}},
+		{Name: "Jane", PagerDutyID: "PX7"},
+		{Name: "John"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Act & Assert
+	cases := map[string]string{
+		"Aliceลาป่วย":                   "Alice",
+		"อลิซ ลาพักร้อน":                "Alice",
+		"al@example.com":                "Alice",
+		"Alicent (afternoon)":           "",
+		"Mail al@example.com's manager": "",
+		"PX7 maintenance":               "",
+		// Names of the same length go to the one mentioned first.
+		"Jane swaps with John": "Jane",
+		"John swaps with Jane": "John",
+	}
+	for summary, expected := range cases {
+		person, found := people.FindPerson(summary)
+		if person.Name != expected || found != (expected != "") {
+			t.Errorf("Expected %q to find %q, got %q", summary, expected, person.Name)
+		}
+	}
+}
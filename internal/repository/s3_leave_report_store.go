@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+type S3Config struct {
+	Bucket string
+	// Prefix is prepended to the object keys, e.g. "reports/".
+	Prefix string
+	// Endpoint overrides the AWS endpoint, e.g. http://localhost:9000 for MinIO.
+	Endpoint string
+	Region   string
+}
+
+// S3LeaveReportStore uploads the leave report exports to an S3 bucket, for Lambda, where the local
+// disk does not outlive the invocation. Credentials come from the usual AWS chain.
+type S3LeaveReportStore struct {
+	client *s3.Client
+	bucket string
+	prefix string
+}
+
+func NewS3LeaveReportStore(config S3Config) (S3LeaveReportStore, error) {
+	var options []func(*awsconfig.LoadOptions) error
+	if config.Region != "" {
+		options = append(options, awsconfig.WithRegion(config.Region))
+	}
+	awsConfig, err := awsconfig.LoadDefaultConfig(context.Background(), options...)
+	if err != nil {
+		return S3LeaveReportStore{}, fmt.Errorf("failed to load AWS config: %v", err)
+	}
+	client := s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+		if config.Endpoint != "" {
+			o.BaseEndpoint = aws.String(config.Endpoint)
+			o.UsePathStyle = true
+		}
+	})
+	return S3LeaveReportStore{client: client, bucket: config.Bucket, prefix: config.Prefix}, nil
+}
+
+func (s S3LeaveReportStore) SaveLeaveReport(name string, csv []byte) error {
+	_, err := s.client.PutObject(context.Background(), &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(s.prefix + name),
+		Body:        bytes.NewReader(csv),
+		ContentType: aws.String("text/csv; charset=utf-8"),
+	})
+	return err
+}
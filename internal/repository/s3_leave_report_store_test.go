@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestS3LeaveReportStore_SaveLeaveReport(t *testing.T) {
+	// Arrange
+	var method, path, contentType string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, contentType = r.Method, r.URL.Path, r.Header.Get("Content-Type")
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+	t.Setenv("AWS_ACCESS_KEY_ID", "local")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "local")
+	store, err := NewS3LeaveReportStore(S3Config{Bucket: "iris", Prefix: "reports/", Endpoint: server.URL, Region: "ap-southeast-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Act
+	err = store.SaveLeaveReport("leave-2025-07.csv", []byte("month,name\n"))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if method != http.MethodPut || path != "/iris/reports/leave-2025-07.csv" || contentType != "text/csv; charset=utf-8" {
+		t.Errorf("Unexpected request %s %s (%s)", method, path, contentType)
+	}
+	if string(body) != "month,name\n" {
+		t.Errorf("Unexpected body %q", body)
+	}
+}
+
+func TestS3LeaveReportStore_SaveLeaveReport_Error(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`)
+	}))
+	defer server.Close()
+	t.Setenv("AWS_ACCESS_KEY_ID", "local")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "local")
+	store, _ := NewS3LeaveReportStore(S3Config{Bucket: "iris", Endpoint: server.URL, Region: "ap-southeast-1"})
+
+	// Act
+	err := store.SaveLeaveReport("leave-2025-07.csv", []byte("month,name\n"))
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
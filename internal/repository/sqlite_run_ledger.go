@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"gitbub.com/tsongpon/iris/internal/service"
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteRunLedger keeps the delivered notifications in a SQLite database, created on first use.
+type SQLiteRunLedger struct {
+	db *sql.DB
+}
+
+const createRunLedgerTable = `CREATE TABLE IF NOT EXISTS run_ledger (
+	team TEXT NOT NULL,
+	job TEXT NOT NULL,
+	date TEXT NOT NULL,
+	channel TEXT NOT NULL,
+	content_hash TEXT NOT NULL,
+	delivered_at TEXT NOT NULL,
+	PRIMARY KEY (team, job, date, channel, content_hash)
+)`
+
+func NewSQLiteRunLedger(path string) (SQLiteRunLedger, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return SQLiteRunLedger{}, fmt.Errorf("failed to open run ledger: %v", err)
+	}
+	if _, err := db.Exec(createRunLedgerTable); err != nil {
+		db.Close()
+		return SQLiteRunLedger{}, fmt.Errorf("failed to create run ledger table: %v", err)
+	}
+	return SQLiteRunLedger{db: db}, nil
+}
+
+func (s SQLiteRunLedger) Delivered(key service.RunKey) (bool, error) {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM run_ledger WHERE team = ? AND job = ? AND date = ? AND channel = ? AND content_hash = ?`,
+		key.Team, key.Job, key.Date, key.Channel, key.ContentHash).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (s SQLiteRunLedger) RecordDelivered(key service.RunKey) error {
+	_, err := s.db.Exec(`INSERT OR IGNORE INTO run_ledger (team, job, date, channel, content_hash, delivered_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		key.Team, key.Job, key.Date, key.Channel, key.ContentHash, time.Now().UTC().Format(time.RFC3339))
+	return err
+}
+
+func (s SQLiteRunLedger) Close() error {
+	return s.db.Close()
+}
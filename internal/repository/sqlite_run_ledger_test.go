@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSQLiteRunLedger_RecordDelivered(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "ledger.db")
+	ledger, err := NewSQLiteRunLedger(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	otherChannel := testRunKey
+	otherChannel.Channel = "email"
+
+	// Act
+	before, beforeErr := ledger.Delivered(testRunKey)
+	recordErr := ledger.RecordDelivered(testRunKey)
+	againErr := ledger.RecordDelivered(testRunKey)
+	ledger.Close()
+	reopened, err := NewSQLiteRunLedger(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer reopened.Close()
+	after, afterErr := reopened.Delivered(testRunKey)
+	other, otherErr := reopened.Delivered(otherChannel)
+
+	// Assert
+	if beforeErr != nil || recordErr != nil || againErr != nil || afterErr != nil || otherErr != nil {
+		t.Fatalf("Expected no errors, got %v, %v, %v, %v and %v", beforeErr, recordErr, againErr, afterErr, otherErr)
+	}
+	if before || !after || other {
+		t.Errorf("Expected only the recorded key to be delivered, got before=%v after=%v other channel=%v", before, after, other)
+	}
+}
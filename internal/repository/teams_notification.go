@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// Teams rejects webhook payloads larger than 28 KB; keep some headroom for the envelope.
+const teamsMaxPayloadBytes = 27 * 1024
+
+// TeamsNotificationRepository posts Adaptive Cards to a Microsoft Teams incoming webhook
+// or a Power Automate "post to a channel when a webhook request is received" workflow URL.
+type TeamsNotificationRepository struct {
+	webhookURL string
+	httpClient *http.Client
+}
+
+func NewTeamsNotificationRepository(webhookURL string) TeamsNotificationRepository {
+	return TeamsNotificationRepository{
+		webhookURL: webhookURL,
+		httpClient: &http.Client{Timeout: defaultWebhookTimeout},
+	}
+}
+
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string            `json:"contentType"`
+	ContentURL  *string           `json:"contentUrl"`
+	Content     teamsAdaptiveCard `json:"content"`
+}
+
+type teamsAdaptiveCard struct {
+	Schema  string           `json:"$schema"`
+	Type    string           `json:"type"`
+	Version string           `json:"version"`
+	Body    []teamsContainer `json:"body"`
+}
+
+type teamsContainer struct {
+	Type      string           `json:"type"`
+	Separator bool             `json:"separator,omitempty"`
+	Items     []teamsTextBlock `json:"items"`
+}
+
+type teamsTextBlock struct {
+	Type   string `json:"type"`
+	Text   string `json:"text"`
+	Weight string `json:"weight,omitempty"`
+	Size   string `json:"size,omitempty"`
+	Wrap   bool   `json:"wrap"`
+}
+
+func (t TeamsNotificationRepository) SendNotification(message string) error {
+	log.Printf("Sending message to Microsoft Teams")
+	for _, card := range buildTeamsMessages(splitSections(message)) {
+		if err := t.post(card); err != nil {
+			log.Printf("Failed to send message: %v", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (t TeamsNotificationRepository) post(message teamsMessage) error {
+	for attempt := 0; ; attempt++ {
+		status, header, body, err := postJSON(t.httpClient, t.webhookURL, message, nil)
+		if err != nil {
+			return err
+		}
+		if status == http.StatusTooManyRequests && attempt < maxRateLimitRetries {
+			wait := retryAfterHeader(header)
+			log.Printf("Teams webhook is rate limited, retrying in %v", wait)
+			sleep(wait)
+			continue
+		}
+		return teamsResponseError(status, body)
+	}
+}
+
+// teamsResponseError interprets a webhook response. Workflow URLs answer 202 Accepted, while
+// legacy connectors answer 200 with body "1" and report delivery failures in a 200 text body.
+func teamsResponseError(status int, body []byte) error {
+	text := strings.TrimSpace(string(body))
+	switch {
+	case status == http.StatusAccepted:
+		return nil
+	case status == http.StatusOK:
+		if text == "1" {
+			return nil
+		}
+		return fmt.Errorf("teams webhook rejected message: %s", text)
+	case status == http.StatusRequestEntityTooLarge:
+		return fmt.Errorf("teams webhook rejected message as too large: %s", text)
+	default:
+		return fmt.Errorf("teams webhook returned status %d: %s", status, text)
+	}
+}
+
+// A card marshals to its envelope plus its containers separated by commas, and a container to
+// its envelope plus its text blocks, so sizes can be added up as parts are appended. The
+// container envelope includes the separator, making the sum an upper bound of the real size.
+var (
+	teamsCardOverhead      = jsonSize(newTeamsMessage([]teamsContainer{}))
+	teamsContainerOverhead = jsonSize(teamsContainer{Type: "Container", Separator: true, Items: []teamsTextBlock{}})
+)
+
+// buildTeamsMessages turns each section into an Adaptive Card container and starts a new
+// card whenever adding the next container would push the payload past the Teams size limit.
+// The card is only marshaled in full when the running estimate is over the limit.
+func buildTeamsMessages(sections []messageSection) []teamsMessage {
+	var messages []teamsMessage
+	var containers []teamsContainer
+	size := teamsCardOverhead
+	for _, section := range sections {
+		for _, container := range teamsContainers(section) {
+			containerSize := jsonSize(container)
+			if len(containers) > 0 {
+				if size+1+containerSize > teamsMaxPayloadBytes && !teamsCardFits(append(append([]teamsContainer{}, containers...), container)) {
+					messages = append(messages, newTeamsMessage(containers))
+					containers, size = nil, teamsCardOverhead
+				} else {
+					size++
+				}
+			}
+			containers = append(containers, container)
+			size += containerSize
+		}
+	}
+	if len(containers) > 0 {
+		messages = append(messages, newTeamsMessage(containers))
+	}
+	return messages
+}
+
+// teamsContainers renders a section, splitting its lines over several containers when the
+// section alone would not fit in one card.
+func teamsContainers(section messageSection) []teamsContainer {
+	title := teamsTextBlock{Type: "TextBlock", Text: section.title, Weight: "Bolder", Size: "Medium", Wrap: true}
+	titleSize := jsonSize(title)
+	var containers []teamsContainer
+	current := teamsContainer{Type: "Container", Items: []teamsTextBlock{title}}
+	size := teamsCardOverhead + teamsContainerOverhead + titleSize
+	for _, line := range section.lines {
+		block := teamsTextBlock{Type: "TextBlock", Text: line, Wrap: true}
+		blockSize := jsonSize(block)
+		if len(current.Items) > 1 && size+1+blockSize > teamsMaxPayloadBytes {
+			next := current
+			next.Items = append(append([]teamsTextBlock{}, current.Items...), block)
+			if !teamsCardFits([]teamsContainer{next}) {
+				containers = append(containers, current)
+				current = teamsContainer{Type: "Container", Items: []teamsTextBlock{title, block}}
+				size = teamsCardOverhead + teamsContainerOverhead + titleSize + 1 + blockSize
+				continue
+			}
+		}
+		current.Items = append(current.Items, block)
+		size += 1 + blockSize
+	}
+	containers = append(containers, current)
+	for i := range containers {
+		containers[i].Separator = true
+	}
+	return containers
+}
+
+func newTeamsMessage(containers []teamsContainer) teamsMessage {
+	if len(containers) > 0 {
+		containers[0].Separator = false
+	}
+	return teamsMessage{
+		Type: "message",
+		Attachments: []teamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content: teamsAdaptiveCard{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.4",
+				Body:    containers,
+			},
+		}},
+	}
+}
+
+// teamsCardFits marshals the whole card, for when the running estimate is over the limit.
+func teamsCardFits(containers []teamsContainer) bool {
+	return teamsPayloadSize(newTeamsMessage(containers)) <= teamsMaxPayloadBytes
+}
+
+func teamsPayloadSize(message teamsMessage) int {
+	return jsonSize(message)
+}
+
+func jsonSize(v any) int {
+	payload, _ := json.Marshal(v)
+	return len(payload)
+}
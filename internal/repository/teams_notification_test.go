@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTeamsNotificationRepository_SendNotification_SectionsBecomeContainers(t *testing.T) {
+	// Arrange
+	var received teamsMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	repo := NewTeamsNotificationRepository(server.URL)
+
+	// Act
+	err := repo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- John Doe\n\n📞 วันนี้ใคร On-Call : (2025-08-12)\n- Jane Doe")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	body := received.Attachments[0].Content.Body
+	if len(body) != 2 {
+		t.Fatalf("Expected 2 containers, got %d", len(body))
+	}
+	if body[0].Items[0].Text != "📅 วันนี้ใครลา : (2025-08-12)" || body[0].Items[1].Text != "- John Doe" {
+		t.Errorf("Unexpected leave container: %+v", body[0])
+	}
+	if !body[1].Separator || body[1].Items[1].Text != "- Jane Doe" {
+		t.Errorf("Unexpected on-call container: %+v", body[1])
+	}
+}
+
+func TestTeamsNotificationRepository_SendNotification_LegacyConnectorError(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Webhook message delivery failed with error: Microsoft Teams endpoint returned HTTP error 413"))
+	}))
+	defer server.Close()
+	repo := NewTeamsNotificationRepository(server.URL)
+
+	// Act
+	err := repo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- John Doe")
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "HTTP error 413") {
+		t.Errorf("Expected delivery failure error, got %v", err)
+	}
+}
+
+func TestTeamsNotificationRepository_SendNotification_UnexpectedOKBody(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><body>Sign in to your account</body></html>"))
+	}))
+	defer server.Close()
+	repo := NewTeamsNotificationRepository(server.URL)
+
+	// Act
+	err := repo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- John Doe")
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "Sign in") {
+		t.Errorf("Expected rejected message error, got %v", err)
+	}
+}
+
+func TestTeamsNotificationRepository_SendNotification_RetriesWhenRateLimited(t *testing.T) {
+	// Arrange
+	sleep = func(time.Duration) {}
+	defer func() { sleep = time.Sleep }()
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("1"))
+	}))
+	defer server.Close()
+	repo := NewTeamsNotificationRepository(server.URL)
+
+	// Act
+	err := repo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- John Doe")
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+}
+
+func TestBuildTeamsMessages_SplitsOversizedSections(t *testing.T) {
+	// Arrange
+	var lines []string
+	for i := 0; i < 2000; i++ {
+		lines = append(lines, "- Someone with a reasonably long display name")
+	}
+	sections := []messageSection{{title: "📅 วันนี้ใครลา : (2025-08-12)", lines: lines}}
+
+	// Act
+	messages := buildTeamsMessages(sections)
+
+	// Assert
+	if len(messages) < 2 {
+		t.Fatalf("Expected message to be split, got %d message", len(messages))
+	}
+	total := 0
+	for _, message := range messages {
+		if size := teamsPayloadSize(message); size > teamsMaxPayloadBytes {
+			t.Errorf("Expected payload within limit, got %d bytes", size)
+		}
+		for _, container := range message.Attachments[0].Content.Body {
+			total += len(container.Items) - 1
+		}
+	}
+	if total != len(lines) {
+		t.Errorf("Expected %d lines delivered, got %d", len(lines), total)
+	}
+}
+
+func TestBuildTeamsMessages_FillsCardsUpToTheLimit(t *testing.T) {
+	// Arrange
+	line := "- Someone with a reasonably long display name"
+	var sections []messageSection
+	for i := 0; i < 300; i++ {
+		sections = append(sections, messageSection{title: "📅 วันนี้ใครลา : (2025-08-12)", lines: []string{line, line}})
+	}
+
+	// Act
+	messages := buildTeamsMessages(sections)
+
+	// Assert
+	if len(messages) < 2 {
+		t.Fatalf("Expected message to be split, got %d message", len(messages))
+	}
+	containerSize := jsonSize(messages[0].Attachments[0].Content.Body[1])
+	for _, message := range messages[:len(messages)-1] {
+		if size := teamsPayloadSize(message); size > teamsMaxPayloadBytes || size+1+containerSize <= teamsMaxPayloadBytes {
+			t.Errorf("Expected a full card within the limit, got %d bytes", size)
+		}
+	}
+}
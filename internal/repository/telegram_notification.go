@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	defaultTelegramAPIURL = "https://api.telegram.org"
+	telegramMaxChars      = 4096
+
+	TelegramParseModeMarkdownV2 = "MarkdownV2"
+	TelegramParseModeHTML       = "HTML"
+)
+
+// telegramMarkdownV2Reserved lists the characters that must be escaped anywhere in MarkdownV2 text,
+// see https://core.telegram.org/bots/api#markdownv2-style
+const telegramMarkdownV2Reserved = "_*[]()~`>#+-=|{}.!\\"
+
+type TelegramConfig struct {
+	BotToken string
+	// ChatID is the numeric chat ID or the @username of a public channel.
+	ChatID string
+	// MessageThreadID posts into a forum topic when non-zero.
+	MessageThreadID int
+	ParseMode       string
+	// APIURL overrides the Bot API endpoint, e.g. for a self-hosted Bot API server.
+	APIURL string
+}
+
+// TelegramNotificationRepository sends the roster with the Telegram Bot API sendMessage method.
+type TelegramNotificationRepository struct {
+	config     TelegramConfig
+	httpClient *http.Client
+}
+
+func NewTelegramNotificationRepository(config TelegramConfig) TelegramNotificationRepository {
+	if config.APIURL == "" {
+		config.APIURL = defaultTelegramAPIURL
+	}
+	if config.ParseMode == "" {
+		config.ParseMode = TelegramParseModeHTML
+	}
+	return TelegramNotificationRepository{
+		config:     config,
+		httpClient: &http.Client{Timeout: defaultWebhookTimeout},
+	}
+}
+
+type telegramSendMessage struct {
+	ChatID          string `json:"chat_id"`
+	MessageThreadID int    `json:"message_thread_id,omitempty"`
+	Text            string `json:"text"`
+	ParseMode       string `json:"parse_mode"`
+}
+
+type telegramResponse struct {
+	OK          bool   `json:"ok"`
+	ErrorCode   int    `json:"error_code"`
+	Description string `json:"description"`
+	Parameters  struct {
+		RetryAfter int `json:"retry_after"`
+	} `json:"parameters"`
+}
+
+func (t TelegramNotificationRepository) SendNotification(message string) error {
+	texts, err := renderTelegramTexts(splitSections(message), t.config.ParseMode)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Sending message to Telegram chat")
+	for _, text := range texts {
+		request := telegramSendMessage{
+			ChatID:          t.config.ChatID,
+			MessageThreadID: t.config.MessageThreadID,
+			Text:            text,
+			ParseMode:       t.config.ParseMode,
+		}
+		if err := t.send(request); err != nil {
+			log.Printf("Failed to send message: %v", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (t TelegramNotificationRepository) send(request telegramSendMessage) error {
+	url := fmt.Sprintf("%s/bot%s/sendMessage", strings.TrimSuffix(t.config.APIURL, "/"), t.config.BotToken)
+	for attempt := 0; ; attempt++ {
+		status, _, body, err := postJSON(t.httpClient, url, request, nil)
+		if err != nil {
+			// The request URL embeds the bot token, keep it out of the logs.
+			return fmt.Errorf("failed to call telegram sendMessage: %v", strings.ReplaceAll(err.Error(), t.config.BotToken, "<token>"))
+		}
+		var response telegramResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return fmt.Errorf("failed to decode telegram response with status %d: %v", status, err)
+		}
+		if response.OK {
+			return nil
+		}
+		if response.ErrorCode == http.StatusTooManyRequests && attempt < maxRateLimitRetries {
+			wait := capBackoff(time.Duration(response.Parameters.RetryAfter) * time.Second)
+			log.Printf("Telegram is rate limited, retrying in %v", wait)
+			sleep(wait)
+			continue
+		}
+		return fmt.Errorf("telegram sendMessage failed with error %d: %s", response.ErrorCode, response.Description)
+	}
+}
+
+// renderTelegramTexts formats each section with a bold heading and packs sections into
+// messages that stay within the Telegram text limit.
+func renderTelegramTexts(sections []messageSection, parseMode string) ([]string, error) {
+	var format func(messageSection) []string
+	switch parseMode {
+	case TelegramParseModeMarkdownV2:
+		format = func(s messageSection) []string {
+			return formatTelegramSection(s, "*"+escapeTelegramMarkdownV2(s.title)+"*", escapeTelegramMarkdownV2)
+		}
+	case TelegramParseModeHTML:
+		format = func(s messageSection) []string {
+			return formatTelegramSection(s, "<b>"+html.EscapeString(s.title)+"</b>", html.EscapeString)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported telegram parse mode: %s", parseMode)
+	}
+
+	var texts []string
+	current := ""
+	for _, section := range sections {
+		for _, chunk := range format(section) {
+			candidate := chunk
+			if current != "" {
+				candidate = current + "\n\n" + chunk
+			}
+			if current != "" && utf8.RuneCountInString(candidate) > telegramMaxChars {
+				texts = append(texts, current)
+				candidate = chunk
+			}
+			current = candidate
+		}
+	}
+	if current != "" {
+		texts = append(texts, current)
+	}
+	return texts, nil
+}
+
+// formatTelegramSection renders a section, repeating the heading when its lines have to be
+// spread over several messages.
+func formatTelegramSection(section messageSection, heading string, escape func(string) string) []string {
+	var chunks []string
+	current := heading
+	for _, line := range section.lines {
+		line = escape(line)
+		if utf8.RuneCountInString(current)+1+utf8.RuneCountInString(line) > telegramMaxChars && current != heading {
+			chunks = append(chunks, current)
+			current = heading
+		}
+		current += "\n" + line
+	}
+	return append(chunks, current)
+}
+
+func escapeTelegramMarkdownV2(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		if strings.ContainsRune(telegramMarkdownV2Reserved, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
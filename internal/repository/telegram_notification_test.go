@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newFakeTelegramServer stands in for the Bot API and records sendMessage requests.
+func newFakeTelegramServer(t *testing.T, token string, responses ...string) (*httptest.Server, *[]telegramSendMessage) {
+	var requests []telegramSendMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bot"+token+"/sendMessage" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"ok":false,"error_code":404,"description":"Not Found"}`))
+			return
+		}
+		var request telegramSendMessage
+		json.NewDecoder(r.Body).Decode(&request)
+		requests = append(requests, request)
+		response := `{"ok":true,"result":{"message_id":1}}`
+		if len(requests) <= len(responses) {
+			response = responses[len(requests)-1]
+		}
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestTelegramNotificationRepository_SendNotification_MarkdownV2(t *testing.T) {
+	// Arrange
+	server, requests := newFakeTelegramServer(t, "123:ABC")
+	repo := NewTelegramNotificationRepository(TelegramConfig{
+		BotToken:        "123:ABC",
+		ChatID:          "-1001234567890",
+		MessageThreadID: 42,
+		ParseMode:       TelegramParseModeMarkdownV2,
+		APIURL:          server.URL,
+	})
+
+	// Act
+	err := repo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- John Doe (half-day) #leave\n\n📞 วันนี้ใคร On-Call : (2025-08-12)\n- Jane_Doe [SRE]")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(*requests) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(*requests))
+	}
+	request := (*requests)[0]
+	if request.ChatID != "-1001234567890" || request.MessageThreadID != 42 || request.ParseMode != "MarkdownV2" {
+		t.Errorf("Unexpected request fields: %+v", request)
+	}
+	expectedText := "*📅 วันนี้ใครลา : \\(2025\\-08\\-12\\)*\n\\- John Doe \\(half\\-day\\) \\#leave\n\n" +
+		"*📞 วันนี้ใคร On\\-Call : \\(2025\\-08\\-12\\)*\n\\- Jane\\_Doe \\[SRE\\]"
+	if request.Text != expectedText {
+		t.Errorf("Expected text '%s', got '%s'", expectedText, request.Text)
+	}
+}
+
+func TestTelegramNotificationRepository_SendNotification_HTML(t *testing.T) {
+	// Arrange
+	server, requests := newFakeTelegramServer(t, "123:ABC")
+	repo := NewTelegramNotificationRepository(TelegramConfig{BotToken: "123:ABC", ChatID: "@iris", APIURL: server.URL})
+
+	// Act
+	err := repo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- R&D <team>")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	request := (*requests)[0]
+	if request.MessageThreadID != 0 || request.ParseMode != "HTML" {
+		t.Errorf("Unexpected request fields: %+v", request)
+	}
+	expectedText := "<b>📅 วันนี้ใครลา : (2025-08-12)</b>\n- R&amp;D &lt;team&gt;"
+	if request.Text != expectedText {
+		t.Errorf("Expected text '%s', got '%s'", expectedText, request.Text)
+	}
+}
+
+func TestTelegramNotificationRepository_SendNotification_RetriesWhenRateLimited(t *testing.T) {
+	// Arrange
+	var waited time.Duration
+	sleep = func(d time.Duration) { waited = d }
+	defer func() { sleep = time.Sleep }()
+	server, requests := newFakeTelegramServer(t, "123:ABC",
+		`{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 3","parameters":{"retry_after":3}}`)
+	repo := NewTelegramNotificationRepository(TelegramConfig{BotToken: "123:ABC", ChatID: "@iris", APIURL: server.URL})
+
+	// Act
+	err := repo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- John Doe")
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if len(*requests) != 2 || waited != 3*time.Second {
+		t.Errorf("Expected a retry after 3s, got %d requests and waited %v", len(*requests), waited)
+	}
+}
+
+func TestTelegramNotificationRepository_SendNotification_APIError(t *testing.T) {
+	// Arrange
+	server, _ := newFakeTelegramServer(t, "123:ABC",
+		`{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`)
+	repo := NewTelegramNotificationRepository(TelegramConfig{BotToken: "123:ABC", ChatID: "@missing", APIURL: server.URL})
+
+	// Act
+	err := repo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- John Doe")
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "chat not found") {
+		t.Errorf("Expected chat not found error, got %v", err)
+	}
+}
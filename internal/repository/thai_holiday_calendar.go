@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"gitbub.com/tsongpon/iris/internal/service"
+)
+
+// thaiFixedHoliday is a holiday observed on the same date every year between the given years.
+type thaiFixedHoliday struct {
+	month time.Month
+	day   int
+	name  string
+	from  int
+	until int
+}
+
+var thaiFixedHolidays = []thaiFixedHoliday{
+	{month: time.January, day: 1, name: "วันขึ้นปีใหม่"},
+	{month: time.April, day: 6, name: "วันจักรี"},
+	{month: time.April, day: 13, name: "วันสงกรานต์"},
+	{month: time.April, day: 14, name: "วันสงกรานต์"},
+	{month: time.April, day: 15, name: "วันสงกรานต์"},
+	{month: time.May, day: 1, name: "วันแรงงานแห่งชาติ"},
+	{month: time.May, day: 4, name: "วันฉัตรมงคล", from: 2020},
+	{month: time.June, day: 3, name: "วันเฉลิมพระชนมพรรษาสมเด็จพระนางเจ้าฯ พระบรมราชินี", from: 2019},
+	{month: time.July, day: 28, name: "วันเฉลิมพระชนมพรรษาพระบาทสมเด็จพระเจ้าอยู่หัว", from: 2017},
+	{month: time.August, day: 12, name: "วันแม่แห่งชาติ"},
+	{month: time.October, day: 13, name: "วันนวมินทรมหาราช", from: 2017},
+	{month: time.October, day: 23, name: "วันปิยมหาราช"},
+	{month: time.December, day: 5, name: "วันพ่อแห่งชาติ"},
+	{month: time.December, day: 10, name: "วันรัฐธรรมนูญ"},
+	{month: time.December, day: 31, name: "วันสิ้นปี"},
+}
+
+// thaiLunarHolidays are the Buddhist holidays of the Thai lunar calendar as announced each year.
+// They cannot be derived from simple rules, so add the next year once it is announced; the
+// calendar fails for years missing here rather than leave these holidays out.
+var thaiLunarHolidays = map[int][]thaiLunarHoliday{
+	2023: {{"2023-03-06", "วันมาฆบูชา"}, {"2023-06-03", "วันวิสาขบูชา"}, {"2023-08-01", "วันอาสาฬหบูชา"}, {"2023-08-02", "วันเข้าพรรษา"}},
+	2024: {{"2024-02-24", "วันมาฆบูชา"}, {"2024-05-22", "วันวิสาขบูชา"}, {"2024-07-20", "วันอาสาฬหบูชา"}, {"2024-07-21", "วันเข้าพรรษา"}},
+	2025: {{"2025-02-12", "วันมาฆบูชา"}, {"2025-05-11", "วันวิสาขบูชา"}, {"2025-07-10", "วันอาสาฬหบูชา"}, {"2025-07-11", "วันเข้าพรรษา"}},
+	2026: {{"2026-03-03", "วันมาฆบูชา"}, {"2026-05-31", "วันวิสาขบูชา"}, {"2026-07-29", "วันอาสาฬหบูชา"}, {"2026-07-30", "วันเข้าพรรษา"}},
+	2027: {{"2027-02-20", "วันมาฆบูชา"}, {"2027-05-20", "วันวิสาขบูชา"}, {"2027-07-18", "วันอาสาฬหบูชา"}, {"2027-07-19", "วันเข้าพรรษา"}},
+}
+
+type thaiLunarHoliday struct {
+	date string
+	name string
+}
+
+// ThaiHolidayCalendar computes Thai public holidays offline: fixed-date holidays from rules, lunar
+// holidays from a bundled table, substitution days (วันหยุดชดเชย) for holidays that fall on a
+// weekend, and one-off special holidays announced by the cabinet from a configured list.
+type ThaiHolidayCalendar struct {
+	special []listedHoliday
+}
+
+// NewThaiHolidayCalendar creates the calendar; specialHolidaysPath is an optional YAML/CSV
+// holiday list of cabinet-announced special holidays.
+func NewThaiHolidayCalendar(specialHolidaysPath string) (ThaiHolidayCalendar, error) {
+	calendar := ThaiHolidayCalendar{}
+	if specialHolidaysPath != "" {
+		special, err := readHolidayFile(specialHolidaysPath)
+		if err != nil {
+			return ThaiHolidayCalendar{}, err
+		}
+		calendar.special = special
+	}
+	return calendar, nil
+}
+
+func (c ThaiHolidayCalendar) GetEvents(asOf time.Time) ([]string, error) {
+	log.Printf("Get event of : %s, from thai holiday calendar", asOf.Format(time.DateOnly))
+	start, end := dayWindow(asOf)
+	events, err := c.events(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return eventSummaries(eventsOverlapping(events, start, end)), nil
+}
+
+func (c ThaiHolidayCalendar) GetEventsBetween(start, end time.Time) ([]string, error) {
+	log.Printf("Get event between : %s and %s, from thai holiday calendar", start.Format(time.RFC3339), end.Format(time.RFC3339))
+	events, err := c.events(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return datedEventSummaries(eventsOverlapping(events, start, end), start.Location()), nil
+}
+
+func (c ThaiHolidayCalendar) ListEvents(start, end time.Time) ([]service.Event, error) {
+	log.Printf("List events between : %s and %s, from thai holiday calendar", start.Format(time.RFC3339), end.Format(time.RFC3339))
+	events, err := c.events(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return serviceEvents(eventsOverlapping(events, start, end), start.Location()), nil
+}
+
+func (c ThaiHolidayCalendar) events(start, end time.Time) ([]calendarEvent, error) {
+	lastYear := end.Add(-time.Nanosecond).Year()
+	for year := start.Year(); year <= lastYear; year++ {
+		if _, ok := thaiLunarHolidays[year]; !ok {
+			return nil, fmt.Errorf("no Thai lunar holidays bundled for %d, add them to thaiLunarHolidays", year)
+		}
+	}
+
+	// Substitution days are placed across the years around the range, so that weekend holidays at
+	// New Year, e.g. Saturday 31 December and Sunday 1 January, get substitution days of their own.
+	var holidays, special []listedHoliday
+	for year := start.Year() - 1; year <= lastYear+1; year++ {
+		holidays = append(holidays, holidaysOf(year)...)
+	}
+	for _, holiday := range c.special {
+		if holiday.first.Year() >= start.Year()-1 && holiday.first.Year() <= lastYear+1 {
+			special = append(special, holiday)
+		}
+	}
+	return listedHolidayEvents(append(withSubstitutionDays(holidays, special), special...), start.Location()), nil
+}
+
+// holidaysOf returns the fixed and lunar holidays of year, without substitution days.
+func holidaysOf(year int) []listedHoliday {
+	var holidays []listedHoliday
+	for _, rule := range thaiFixedHolidays {
+		if (rule.from != 0 && year < rule.from) || (rule.until != 0 && year > rule.until) {
+			continue
+		}
+		date := time.Date(year, rule.month, rule.day, 0, 0, 0, 0, time.UTC)
+		holidays = append(holidays, listedHoliday{name: rule.name, first: date, last: date})
+	}
+	for _, holiday := range thaiLunarHolidays[year] {
+		date, _ := time.Parse(time.DateOnly, holiday.date)
+		holidays = append(holidays, listedHoliday{name: holiday.name, first: date, last: date})
+	}
+	return holidays
+}
+
+// thaiHolidaysWithoutSubstitution are never given a substitution day when they fall on a weekend.
+var thaiHolidaysWithoutSubstitution = map[string]bool{"วันเข้าพรรษา": true}
+
+// withSubstitutionDays adds a substitution day for every weekend date a holiday falls on, on the
+// next day that is neither a weekend, a holiday nor already a substitution day. Holidays sharing a
+// weekend date get a single substitution day. Special holidays occupy days but are not substituted
+// themselves.
+func withSubstitutionDays(holidays, special []listedHoliday) []listedHoliday {
+	sort.SliceStable(holidays, func(i, j int) bool { return holidays[i].first.Before(holidays[j].first) })
+
+	occupied := map[time.Time]bool{}
+	for _, holiday := range append(append([]listedHoliday{}, holidays...), special...) {
+		for day := holiday.first; !day.After(holiday.last); day = day.AddDate(0, 0, 1) {
+			occupied[day] = true
+		}
+	}
+
+	var weekendDates []time.Time
+	names := map[time.Time][]string{}
+	for _, holiday := range holidays {
+		if thaiHolidaysWithoutSubstitution[holiday.name] {
+			continue
+		}
+		for day := holiday.first; !day.After(holiday.last); day = day.AddDate(0, 0, 1) {
+			if !isWeekend(day) {
+				continue
+			}
+			if _, ok := names[day]; !ok {
+				weekendDates = append(weekendDates, day)
+			}
+			names[day] = append(names[day], holiday.name)
+		}
+	}
+	sort.Slice(weekendDates, func(i, j int) bool { return weekendDates[i].Before(weekendDates[j]) })
+
+	result := append([]listedHoliday{}, holidays...)
+	for _, date := range weekendDates {
+		day := date.AddDate(0, 0, 1)
+		for isWeekend(day) || occupied[day] {
+			day = day.AddDate(0, 0, 1)
+		}
+		occupied[day] = true
+		result = append(result, listedHoliday{name: "วันหยุดชดเชย" + strings.Join(names[date], " และ"), first: day, last: day})
+	}
+	return result
+}
+
+func isWeekend(date time.Time) bool {
+	return date.Weekday() == time.Saturday || date.Weekday() == time.Sunday
+}
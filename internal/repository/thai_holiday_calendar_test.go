@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestThaiHolidayCalendar_GetEventsBetween_2025(t *testing.T) {
+	// Arrange
+	calendar, _ := NewThaiHolidayCalendar("")
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	// Act
+	holidays, err := calendar.GetEventsBetween(time.Date(2025, 1, 1, 0, 0, 0, 0, bangkok), time.Date(2026, 1, 1, 0, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []string{
+		"2025-01-01: วันขึ้นปีใหม่",
+		"2025-02-12: วันมาฆบูชา",
+		"2025-04-06: วันจักรี",
+		"2025-04-07: วันหยุดชดเชยวันจักรี",
+		"2025-04-13: วันสงกรานต์",
+		"2025-04-14: วันสงกรานต์",
+		"2025-04-15: วันสงกรานต์",
+		"2025-04-16: วันหยุดชดเชยวันสงกรานต์",
+		"2025-05-01: วันแรงงานแห่งชาติ",
+		"2025-05-04: วันฉัตรมงคล",
+		"2025-05-05: วันหยุดชดเชยวันฉัตรมงคล",
+		"2025-05-11: วันวิสาขบูชา",
+		"2025-05-12: วันหยุดชดเชยวันวิสาขบูชา",
+		"2025-06-03: วันเฉลิมพระชนมพรรษาสมเด็จพระนางเจ้าฯ พระบรมราชินี",
+		"2025-07-10: วันอาสาฬหบูชา",
+		"2025-07-11: วันเข้าพรรษา",
+		"2025-07-28: วันเฉลิมพระชนมพรรษาพระบาทสมเด็จพระเจ้าอยู่หัว",
+		"2025-08-12: วันแม่แห่งชาติ",
+		"2025-10-13: วันนวมินทรมหาราช",
+		"2025-10-23: วันปิยมหาราช",
+		"2025-12-05: วันพ่อแห่งชาติ",
+		"2025-12-10: วันรัฐธรรมนูญ",
+		"2025-12-31: วันสิ้นปี",
+	}
+	if !reflect.DeepEqual(holidays, expected) {
+		t.Errorf("Expected %v, got %v", expected, holidays)
+	}
+}
+
+func TestWithSubstitutionDays_ConsecutiveWeekendHolidaysGetSeparateSubstitutes(t *testing.T) {
+	// Arrange: Songkran 2029 falls on Friday to Sunday, so the 14th and 15th are substituted.
+	var holidays []listedHoliday
+	for day := 13; day <= 15; day++ {
+		date := time.Date(2029, 4, day, 0, 0, 0, 0, time.UTC)
+		holidays = append(holidays, listedHoliday{name: "วันสงกรานต์", first: date, last: date})
+	}
+
+	// Act
+	result := withSubstitutionDays(holidays, nil)
+
+	// Assert
+	monday, tuesday := time.Date(2029, 4, 16, 0, 0, 0, 0, time.UTC), time.Date(2029, 4, 17, 0, 0, 0, 0, time.UTC)
+	if len(result) != 5 || !result[3].first.Equal(monday) || !result[4].first.Equal(tuesday) || result[4].name != "วันหยุดชดเชยวันสงกรานต์" {
+		t.Errorf("Expected substitution days on Monday and Tuesday, got %+v", result)
+	}
+}
+
+func TestThaiHolidayCalendar_GetEventsBetween_NewYearWeekendGetsTwoSubstitutes(t *testing.T) {
+	// Arrange: Saturday 2022-12-31 and Sunday 2023-01-01 are substituted on the 2nd and the 3rd.
+	calendar, _ := NewThaiHolidayCalendar("")
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	// Act
+	holidays, err := calendar.GetEventsBetween(time.Date(2023, 1, 1, 0, 0, 0, 0, bangkok), time.Date(2023, 1, 5, 0, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []string{
+		"2023-01-01: วันขึ้นปีใหม่",
+		"2023-01-02: วันหยุดชดเชยวันสิ้นปี",
+		"2023-01-03: วันหยุดชดเชยวันขึ้นปีใหม่",
+	}
+	if !reflect.DeepEqual(holidays, expected) {
+		t.Errorf("Expected %v, got %v", expected, holidays)
+	}
+}
+
+func TestThaiLunarHolidays_CoverCurrentAndNextYear(t *testing.T) {
+	// Announce the lunar holidays of next year before it starts, or the holiday calendar fails.
+	for _, year := range []int{time.Now().Year(), time.Now().Year() + 1} {
+		if len(thaiLunarHolidays[year]) != 4 {
+			t.Errorf("Expected the four Thai lunar holidays of %d, got %v", year, thaiLunarHolidays[year])
+		}
+	}
+}
+
+func TestThaiHolidayCalendar_GetEventsBetween_YearWithoutLunarHolidays(t *testing.T) {
+	// Arrange
+	calendar, _ := NewThaiHolidayCalendar("")
+
+	// Act
+	_, err := calendar.GetEventsBetween(time.Date(2035, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2035, 2, 1, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestThaiHolidayCalendar_GetEventsBetween_SharedWeekendDateGetsOneSubstitute(t *testing.T) {
+	// Arrange: on Saturday 2023-06-03 Visakha Bucha fell on the Queen's birthday; only Monday the
+	// 5th was given.
+	calendar, _ := NewThaiHolidayCalendar("")
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	// Act
+	holidays, err := calendar.GetEventsBetween(time.Date(2023, 6, 1, 0, 0, 0, 0, bangkok), time.Date(2023, 7, 1, 0, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []string{
+		"2023-06-03: วันเฉลิมพระชนมพรรษาสมเด็จพระนางเจ้าฯ พระบรมราชินี",
+		"2023-06-03: วันวิสาขบูชา",
+		"2023-06-05: วันหยุดชดเชยวันเฉลิมพระชนมพรรษาสมเด็จพระนางเจ้าฯ พระบรมราชินี และวันวิสาขบูชา",
+	}
+	if !reflect.DeepEqual(holidays, expected) {
+		t.Errorf("Expected %v, got %v", expected, holidays)
+	}
+}
+
+func TestWithSubstitutionDays_KhaoPhansaIsNotSubstituted(t *testing.T) {
+	// Arrange
+	sunday := time.Date(2025, 8, 10, 0, 0, 0, 0, time.UTC)
+	holidays := []listedHoliday{{name: "วันเข้าพรรษา", first: sunday, last: sunday}}
+
+	// Act
+	result := withSubstitutionDays(holidays, nil)
+
+	// Assert
+	if len(result) != 1 {
+		t.Errorf("Expected no substitution day, got %+v", result)
+	}
+}
+
+func TestWithSubstitutionDays_MultiDayHolidayEndingOnWeekend(t *testing.T) {
+	// Arrange: Friday to Sunday.
+	friday := time.Date(2025, 8, 8, 0, 0, 0, 0, time.UTC)
+	holidays := []listedHoliday{{name: "Long holiday", first: friday, last: friday.AddDate(0, 0, 2)}}
+
+	// Act
+	result := withSubstitutionDays(holidays, nil)
+
+	// Assert
+	if len(result) != 3 || !result[1].first.Equal(friday.AddDate(0, 0, 3)) || !result[2].first.Equal(friday.AddDate(0, 0, 4)) {
+		t.Errorf("Expected substitution days on Monday and Tuesday, got %+v", result)
+	}
+}
+
+func TestThaiHolidayCalendar_SpecialHolidaysFromConfig(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "special.yaml")
+	os.WriteFile(path, []byte("holidays:\n  - date: 2025-06-02\n    name: วันหยุดพิเศษ (มติ ครม.)\n"), 0o600)
+	calendar, err := NewThaiHolidayCalendar(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	// Act
+	holidays, err := calendar.GetEventsBetween(time.Date(2025, 6, 1, 0, 0, 0, 0, bangkok), time.Date(2025, 6, 5, 0, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []string{"2025-06-02: วันหยุดพิเศษ (มติ ครม.)", "2025-06-03: วันเฉลิมพระชนมพรรษาสมเด็จพระนางเจ้าฯ พระบรมราชินี"}
+	if !reflect.DeepEqual(holidays, expected) {
+		t.Errorf("Expected %v, got %v", expected, holidays)
+	}
+}
+
+func TestWithSubstitutionDays_SkipsSpecialHolidays(t *testing.T) {
+	// Arrange: a Sunday holiday followed by a special holiday on Monday.
+	sunday := time.Date(2025, 8, 10, 0, 0, 0, 0, time.UTC)
+	monday := sunday.AddDate(0, 0, 1)
+	holidays := []listedHoliday{{name: "Sunday holiday", first: sunday, last: sunday}}
+	special := []listedHoliday{{name: "Special", first: monday, last: monday}}
+
+	// Act
+	result := withSubstitutionDays(holidays, special)
+
+	// Assert
+	if len(result) != 2 || !result[1].first.Equal(monday.AddDate(0, 0, 1)) {
+		t.Errorf("Expected substitution on Tuesday, got %+v", result)
+	}
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// windowsTimeZones maps the Windows time zone names used by Outlook and Exchange exports to IANA
+// names. Only zones our teams are likely to meet are listed; see the CLDR windowsZones table.
+var windowsTimeZones = map[string]string{
+	"SE Asia Standard Time":          "Asia/Bangkok",
+	"Singapore Standard Time":        "Asia/Singapore",
+	"China Standard Time":            "Asia/Shanghai",
+	"Taipei Standard Time":           "Asia/Taipei",
+	"Tokyo Standard Time":            "Asia/Tokyo",
+	"Korea Standard Time":            "Asia/Seoul",
+	"India Standard Time":            "Asia/Kolkata",
+	"Myanmar Standard Time":          "Asia/Yangon",
+	"N. Central Asia Standard Time":  "Asia/Novosibirsk",
+	"AUS Eastern Standard Time":      "Australia/Sydney",
+	"New Zealand Standard Time":      "Pacific/Auckland",
+	"GMT Standard Time":              "Europe/London",
+	"Greenwich Standard Time":        "Atlantic/Reykjavik",
+	"W. Europe Standard Time":        "Europe/Berlin",
+	"Romance Standard Time":          "Europe/Paris",
+	"Central Europe Standard Time":   "Europe/Budapest",
+	"E. Europe Standard Time":        "Europe/Chisinau",
+	"FLE Standard Time":              "Europe/Kiev",
+	"Russian Standard Time":          "Europe/Moscow",
+	"Arabian Standard Time":          "Asia/Dubai",
+	"Eastern Standard Time":          "America/New_York",
+	"Central Standard Time":          "America/Chicago",
+	"Mountain Standard Time":         "America/Denver",
+	"Pacific Standard Time":          "America/Los_Angeles",
+	"Hawaiian Standard Time":         "Pacific/Honolulu",
+	"E. South America Standard Time": "America/Sao_Paulo",
+	"UTC":                            "UTC",
+	"Coordinated Universal Time":     "UTC",
+}
+
+// loadLocation resolves IANA and Windows time zone names.
+func loadLocation(name string) (*time.Location, error) {
+	name = strings.Trim(name, `"`)
+	if iana, ok := windowsTimeZones[name]; ok {
+		name = iana
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q: %v", name, err)
+	}
+	return loc, nil
+}
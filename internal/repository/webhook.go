@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultWebhookTimeout    = 10 * time.Second
+	maxRateLimitRetries      = 3
+	maxRateLimitRetryBackoff = 30 * time.Second
+)
+
+// sleep is replaced in tests so rate limit back-off does not slow them down.
+var sleep = time.Sleep
+
+// postJSON sends payload as a JSON request body and returns the response status and body.
+func postJSON(client *http.Client, url string, payload any, header http.Header) (int, http.Header, []byte, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to encode payload: %v", err)
+	}
+	return postRawJSON(client, url, body, header)
+}
+
+// postRawJSON sends an already encoded JSON body, for callers that need the exact bytes on the wire.
+func postRawJSON(client *http.Client, url string, body []byte, header http.Header) (int, http.Header, []byte, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, resp.Header, nil, fmt.Errorf("failed to read response: %v", err)
+	}
+	return resp.StatusCode, resp.Header, respBody, nil
+}
+
+// retryAfterHeader reads the Retry-After header in its delay-seconds form.
+func retryAfterHeader(header http.Header) time.Duration {
+	seconds, err := strconv.ParseFloat(header.Get("Retry-After"), 64)
+	if err != nil || seconds < 0 {
+		return time.Second
+	}
+	return capBackoff(time.Duration(seconds * float64(time.Second)))
+}
+
+func capBackoff(d time.Duration) time.Duration {
+	if d > maxRateLimitRetryBackoff {
+		return maxRateLimitRetryBackoff
+	}
+	return d
+}
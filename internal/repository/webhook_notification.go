@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"gitbub.com/tsongpon/iris/pkg/webhook"
+)
+
+type WebhookConfig struct {
+	URLs []string
+	// Secret signs every request; receivers verify it with the pkg/webhook helpers.
+	Secret  string
+	Team    string
+	Headers map[string]string
+	Timeout time.Duration
+	// Date is the roster date the payload carries, so a retry after midnight still names the
+	// roster's day.
+	Date time.Time
+}
+
+// WebhookNotificationRepository posts each notification as signed JSON to arbitrary HTTP endpoints.
+type WebhookNotificationRepository struct {
+	config     WebhookConfig
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+func NewWebhookNotificationRepository(config WebhookConfig) (WebhookNotificationRepository, error) {
+	// Signing with an empty key gives signatures anyone can forge, so receivers would accept them.
+	if config.Secret == "" {
+		return WebhookNotificationRepository{}, fmt.Errorf("webhook secret is required")
+	}
+	if config.Timeout == 0 {
+		config.Timeout = defaultWebhookTimeout
+	}
+	return WebhookNotificationRepository{
+		config:     config,
+		httpClient: &http.Client{Timeout: config.Timeout},
+		now:        time.Now,
+	}, nil
+}
+
+func (w WebhookNotificationRepository) SendNotification(message string) error {
+	if len(w.config.URLs) == 0 {
+		return fmt.Errorf("no webhook urls configured")
+	}
+	body, err := json.Marshal(buildWebhookPayload(message, w.config.Team, w.config.Date))
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %v", err)
+	}
+
+	header := http.Header{}
+	for key, value := range w.config.Headers {
+		header.Set(key, value)
+	}
+	now := w.now()
+	header.Set(webhook.TimestampHeader, fmt.Sprint(now.Unix()))
+	header.Set(webhook.SignatureHeader, webhook.Sign([]byte(w.config.Secret), now, body))
+
+	var errs []error
+	for _, url := range w.config.URLs {
+		log.Printf("Sending message to webhook %s", url)
+		status, _, respBody, err := postRawJSON(w.httpClient, url, body, header)
+		if err == nil && (status < 200 || status >= 300) {
+			err = fmt.Errorf("webhook %s returned status %d: %s", url, status, strings.TrimSpace(string(respBody)))
+		}
+		if err != nil {
+			log.Printf("Failed to send message: %v", err)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func buildWebhookPayload(message, team string, date time.Time) webhook.Payload {
+	payload := webhook.Payload{Team: team, Date: date.Format(time.DateOnly), Text: message}
+	for _, section := range splitSections(message) {
+		items := []string{}
+		for _, line := range section.lines {
+			items = append(items, strings.TrimPrefix(line, "- "))
+		}
+		payload.Sections = append(payload.Sections, webhook.Section{Title: section.title, Items: items})
+	}
+	return payload
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gitbub.com/tsongpon/iris/pkg/webhook"
+)
+
+func TestWebhookNotificationRepository_SendNotification_SignedPayload(t *testing.T) {
+	// Arrange
+	var payload webhook.Payload
+	var verifyErr error
+	var apiKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey = r.Header.Get("X-Api-Key")
+		body, err := webhook.VerifyRequest(r, []byte("s3cret"), 0)
+		verifyErr = err
+		json.Unmarshal(body, &payload)
+	}))
+	defer server.Close()
+	repo, _ := NewWebhookNotificationRepository(WebhookConfig{
+		URLs:    []string{server.URL},
+		Secret:  "s3cret",
+		Team:    "backend",
+		Headers: map[string]string{"X-Api-Key": "abc"},
+		Date:    time.Date(2025, 8, 12, 8, 0, 0, 0, time.UTC),
+	})
+	// A retry after midnight still carries the roster's date.
+	repo.now = func() time.Time { return time.Date(2025, 8, 13, 0, 30, 0, 0, time.UTC) }
+
+	// Act
+	err := repo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- John Doe\n\n📞 วันนี้ใคร On-Call : (2025-08-12)\n- Jane Doe")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if verifyErr != nil {
+		t.Errorf("Expected signature to verify, got %v", verifyErr)
+	}
+	if apiKey != "abc" {
+		t.Errorf("Expected configured header to be sent, got %q", apiKey)
+	}
+	if payload.Team != "backend" || payload.Date != "2025-08-12" {
+		t.Errorf("Unexpected team or date: %+v", payload)
+	}
+	if len(payload.Sections) != 2 || payload.Sections[1].Title != "📞 วันนี้ใคร On-Call : (2025-08-12)" || payload.Sections[1].Items[0] != "Jane Doe" {
+		t.Errorf("Unexpected sections: %+v", payload.Sections)
+	}
+}
+
+func TestWebhookNotificationRepository_SendNotification_ReportsFailingEndpoint(t *testing.T) {
+	// Arrange
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ok.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	repo, _ := NewWebhookNotificationRepository(WebhookConfig{URLs: []string{ok.URL, failing.URL}, Secret: "s3cret"})
+
+	// Act
+	err := repo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- John Doe")
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestNewWebhookNotificationRepository_RequiresSecret(t *testing.T) {
+	// Act
+	_, err := NewWebhookNotificationRepository(WebhookConfig{URLs: []string{"http://localhost"}})
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// longBreakDays is the shortest break without a holiday worth a reminder; a plain weekend is not.
+const longBreakDays = 3
+
+// NotifyUpcomingBreak reminds the team on the last working day before a holiday or long weekend
+// how many days off are coming, when work resumes and who is on call during the break.
+func (e EventNotifyService) NotifyUpcomingBreak(asOf time.Time) error {
+	today := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, asOf.Location())
+	if isWeekend(today) {
+		return nil
+	}
+	holidays, err := e.holidayEventRepository.ListEvents(today, today.AddDate(0, 0, maxDaysToNextWorkingDay))
+	if err != nil {
+		log.Printf("Error while getting holiday events: %v", err)
+		return fmt.Errorf("Error while getting holiday events: %v", err)
+	}
+	if len(eventSummariesBetween(holidays, today, today.AddDate(0, 0, 1))) > 0 {
+		return nil
+	}
+
+	breakStart := today.AddDate(0, 0, 1)
+	returnDay := breakStart
+	daysOff := 0
+	hasHoliday := false
+	for {
+		isHoliday := len(eventSummariesBetween(holidays, returnDay, returnDay.AddDate(0, 0, 1))) > 0
+		if !isHoliday && !isWeekend(returnDay) {
+			break
+		}
+		hasHoliday = hasHoliday || isHoliday
+		daysOff++
+		returnDay = returnDay.AddDate(0, 0, 1)
+		if daysOff >= maxDaysToNextWorkingDay {
+			return fmt.Errorf("no working day within %d days after %s", maxDaysToNextWorkingDay, asOf.Format(time.DateOnly))
+		}
+	}
+	if !hasHoliday && daysOff < longBreakDays {
+		log.Println("No holiday or long weekend after " + asOf.Format(time.DateOnly))
+		return nil
+	}
+	log.Printf("A break of %d days starts on %s", daysOff, breakStart.Format(time.DateOnly))
+
+	onCallEvents, err := e.onCallEventRepository.ListEvents(breakStart, returnDay)
+	if err != nil {
+		log.Printf("Error while getting on-call events: %v", err)
+		return fmt.Errorf("Error while getting on-call events: %v", err)
+	}
+
+	title := "วันหยุด"
+	if daysOff >= longBreakDays {
+		title = "วันหยุดยาว"
+	}
+	message := fmt.Sprintf("🏖️ %s %d วัน : (%s - %s)\n", title, daysOff, breakStart.Format(time.DateOnly), returnDay.AddDate(0, 0, -1).Format(time.DateOnly))
+	message += "- กลับมาทำงาน " + returnDay.Format(time.DateOnly) + " " + weekdayTh(returnDay.Weekday())
+	message += "\n\n📞 ใคร On-Call ช่วงวันหยุด\n" + strings.Join(daysOffCoverage(breakStart, returnDay, holidays, onCallEvents), "\n")
+
+	err = e.notificationRepository.SendNotification(message)
+	if err != nil {
+		log.Printf("Failed to send notification: %v", err)
+		return fmt.Errorf("Error while sending notification: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEventNotifyService_NotifyUpcomingBreak_LongWeekend(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	holidayRepo := &MockEventRepository{listed: []Event{
+		allDay("วันหยุดพิเศษ", time.Date(2025, 8, 11, 0, 0, 0, 0, bangkok), 1),
+		allDay("วันแม่แห่งชาติ", time.Date(2025, 8, 12, 0, 0, 0, 0, bangkok), 1),
+	}}
+	onCallRepo := &MockEventRepository{listed: []Event{
+		{Summary: "Alice", Start: time.Date(2025, 8, 4, 9, 0, 0, 0, bangkok), End: time.Date(2025, 8, 11, 9, 0, 0, 0, bangkok)},
+		{Summary: "Bob", Start: time.Date(2025, 8, 11, 9, 0, 0, 0, bangkok), End: time.Date(2025, 8, 18, 9, 0, 0, 0, bangkok)},
+	}}
+	mockNotification := &MockNotificationRepository{}
+	service := NewEventNotifyService(&MockEventRepository{}, holidayRepo, onCallRepo, mockNotification)
+
+	// Act
+	err := service.NotifyUpcomingBreak(time.Date(2025, 8, 8, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedMessage := "🏖️ วันหยุดยาว 4 วัน : (2025-08-09 - 2025-08-12)\n" +
+		"- กลับมาทำงาน 2025-08-13 พุธ\n\n" +
+		"📞 ใคร On-Call ช่วงวันหยุด\n" +
+		"- 2025-08-09 เสาร์: Alice\n" +
+		"- 2025-08-10 อาทิตย์: Alice\n" +
+		"- 2025-08-11 จันทร์ (วันหยุดพิเศษ): Bob\n" +
+		"- 2025-08-12 อังคาร (วันแม่แห่งชาติ): Bob"
+	if mockNotification.sentMessage != expectedMessage {
+		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
+	}
+}
+
+func TestEventNotifyService_NotifyUpcomingBreak_MidweekHoliday(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	holidayRepo := &MockEventRepository{listed: []Event{allDay("วันแม่แห่งชาติ", time.Date(2025, 8, 12, 0, 0, 0, 0, bangkok), 1)}}
+	mockNotification := &MockNotificationRepository{}
+	service := NewEventNotifyService(&MockEventRepository{}, holidayRepo, &MockEventRepository{}, mockNotification)
+
+	// Act
+	err := service.NotifyUpcomingBreak(time.Date(2025, 8, 11, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedMessage := "🏖️ วันหยุด 1 วัน : (2025-08-12 - 2025-08-12)\n" +
+		"- กลับมาทำงาน 2025-08-13 พุธ\n\n" +
+		"📞 ใคร On-Call ช่วงวันหยุด\n" +
+		"- 2025-08-12 อังคาร (วันแม่แห่งชาติ): ยังไม่มีคน On-Call"
+	if mockNotification.sentMessage != expectedMessage {
+		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
+	}
+}
+
+func TestEventNotifyService_NotifyUpcomingBreak_NoReminder(t *testing.T) {
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	holidayRepo := &MockEventRepository{listed: []Event{allDay("วันแม่แห่งชาติ", time.Date(2025, 8, 12, 0, 0, 0, 0, bangkok), 1)}}
+
+	for _, asOf := range []time.Time{
+		time.Date(2025, 8, 15, 8, 0, 0, 0, bangkok), // Friday before a plain weekend
+		time.Date(2025, 8, 12, 8, 0, 0, 0, bangkok), // the holiday itself
+		time.Date(2025, 8, 13, 8, 0, 0, 0, bangkok), // Wednesday before a working day
+		time.Date(2025, 8, 16, 8, 0, 0, 0, bangkok), // Saturday
+	} {
+		// Arrange
+		mockNotification := &MockNotificationRepository{}
+		service := NewEventNotifyService(&MockEventRepository{}, holidayRepo, &MockEventRepository{}, mockNotification)
+
+		// Act
+		err := service.NotifyUpcomingBreak(asOf)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if mockNotification.numberOfCalls != 0 {
+			t.Errorf("Expected no reminder on %s, got '%s'", asOf.Format(time.DateOnly), mockNotification.sentMessage)
+		}
+	}
+}
+
+func TestEventNotifyService_NotifyUpcomingBreak_HolidayError(t *testing.T) {
+	// Arrange
+	mockNotification := &MockNotificationRepository{}
+	service := NewEventNotifyService(&MockEventRepository{}, &MockEventRepository{err: errors.New("calendar unavailable")}, &MockEventRepository{}, mockNotification)
+
+	// Act
+	err := service.NotifyUpcomingBreak(time.Date(2025, 8, 8, 8, 0, 0, 0, time.UTC))
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// offDayBlock is a run of consecutive weekend days and holidays, first..last inclusive.
+type offDayBlock struct {
+	first    time.Time
+	last     time.Time
+	holidays int
+}
+
+func (b offDayBlock) days() int {
+	return daysBetween(b.first, b.last) + 1
+}
+
+// offDayBlocks groups the weekend days and holidays in [from, until) into consecutive blocks.
+func offDayBlocks(from, until time.Time, holidays []Event) []offDayBlock {
+	var blocks []offDayBlock
+	for day := from; day.Before(until); day = day.AddDate(0, 0, 1) {
+		isHoliday := len(eventSummariesBetween(holidays, day, day.AddDate(0, 0, 1))) > 0
+		if !isHoliday && !isWeekend(day) {
+			continue
+		}
+		if n := len(blocks); n > 0 && sameDate(blocks[n-1].last.AddDate(0, 0, 1), day) {
+			blocks[n-1].last = day
+		} else {
+			blocks = append(blocks, offDayBlock{first: day, last: day})
+		}
+		if isHoliday {
+			blocks[len(blocks)-1].holidays++
+		}
+	}
+	return blocks
+}
+
+// longBreakSections describes the breaks of the month starting at monthStart for the monthly
+// holiday announcement: bridge days, single working days between a holiday and a weekend that
+// turn two breaks into one, and blocks of three or more days off that include a holiday, such as
+// Songkran. holidays must cover a week either side of the month so breaks across its edges are whole.
+func longBreakSections(monthStart time.Time, holidays []Event) []string {
+	monthEnd := monthStart.AddDate(0, 1, 0)
+	blocks := offDayBlocks(monthStart.AddDate(0, 0, -7), monthEnd.AddDate(0, 0, 7), holidays)
+
+	var bridges []string
+	for i := 1; i < len(blocks); i++ {
+		before, after := blocks[i-1], blocks[i]
+		bridge := before.last.AddDate(0, 0, 1)
+		if !sameDate(bridge.AddDate(0, 0, 1), after.first) || before.holidays+after.holidays == 0 {
+			continue
+		}
+		if bridge.Before(monthStart) || !bridge.Before(monthEnd) {
+			continue
+		}
+		merged := offDayBlock{first: before.first, last: after.last}
+		bridges = append(bridges, fmt.Sprintf("- ลา %s %s หยุดยาว %d วัน (%s)",
+			weekdayTh(bridge.Weekday()), formatThaiDate(bridge), merged.days(), formatThaiDateRange(merged.first, merged.last)))
+	}
+
+	var breaks []string
+	for _, block := range blocks {
+		if block.holidays == 0 || block.days() < longBreakDays {
+			continue
+		}
+		if block.last.Before(monthStart) || !block.first.Before(monthEnd) {
+			continue
+		}
+		breaks = append(breaks, fmt.Sprintf("- %s (%d วัน)", formatThaiDateRange(block.first, block.last), block.days()))
+	}
+
+	var sections []string
+	if len(breaks) > 0 {
+		sections = append(sections, "🏖️ ช่วงวันหยุดยาว\n"+strings.Join(breaks, "\n"))
+	}
+	if len(bridges) > 0 {
+		sections = append(sections, "🌉 วันลาเชื่อมวันหยุด\n"+strings.Join(bridges, "\n"))
+	}
+	return sections
+}
+
+func formatDateRange(first, last time.Time) string {
+	if sameDate(first, last) {
+		return first.Format(time.DateOnly)
+	}
+	return first.Format(time.DateOnly) + " - " + last.Format(time.DateOnly)
+}
+
+// daysBetween counts calendar days from a to b, ignoring the time of day and DST changes.
+func daysBetween(a, b time.Time) int {
+	a = time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	b = time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
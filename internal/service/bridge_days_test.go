@@ -0,0 +1,71 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventNotifyService_Notify_EndOfMonth_SuggestsBridgeDays(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	mockNotification := &MockNotificationRepository{}
+	mockHolidayRepo := &MockEventRepository{
+		listed: []Event{allDay("วันแม่แห่งชาติ", time.Date(2025, 8, 12, 0, 0, 0, 0, bangkok), 1)},
+	}
+	service := NewEventNotifyService(&MockEventRepository{}, mockHolidayRepo, &MockEventRepository{}, mockNotification)
+
+	// Act
+	err := service.Notify(time.Date(2025, 7, 31, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// Taking Monday off joins the weekend and the Tuesday holiday.
+	expectedMessage := "มีวันหยุด 1 วันเดือน สิงหาคม 🎉🏖️:\n- อังคาร 12 ส.ค.: วันแม่แห่งชาติ\n\n" +
+		"🌉 วันลาเชื่อมวันหยุด\n" +
+		"- ลา จันทร์ 11 ส.ค. หยุดยาว 4 วัน (9–12 ส.ค.)"
+	if mockNotification.sentMessage != expectedMessage {
+		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
+	}
+}
+
+func TestLongBreakSections_SongkranIsOneBlock(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	holidays := []Event{
+		allDay("วันจักรี", time.Date(2025, 4, 6, 0, 0, 0, 0, bangkok), 1),
+		allDay("วันหยุดชดเชยวันจักรี", time.Date(2025, 4, 7, 0, 0, 0, 0, bangkok), 1),
+		allDay("วันสงกรานต์", time.Date(2025, 4, 13, 0, 0, 0, 0, bangkok), 3),
+		allDay("วันหยุดชดเชยวันสงกรานต์", time.Date(2025, 4, 16, 0, 0, 0, 0, bangkok), 1),
+	}
+
+	// Act
+	sections := longBreakSections(time.Date(2025, 4, 1, 0, 0, 0, 0, bangkok), holidays)
+
+	// Assert
+	if len(sections) != 1 {
+		t.Fatalf("Expected only the long breaks section, got %v", sections)
+	}
+	expected := "🏖️ ช่วงวันหยุดยาว\n" +
+		"- 5–7 เม.ย. (3 วัน)\n" +
+		"- 12–16 เม.ย. (5 วัน)"
+	if sections[0] != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, sections[0])
+	}
+}
+
+func TestLongBreakSections_ThursdayHoliday(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	holidays := []Event{allDay("วันพ่อแห่งชาติ", time.Date(2024, 12, 5, 0, 0, 0, 0, bangkok), 1)}
+
+	// Act
+	sections := longBreakSections(time.Date(2024, 12, 1, 0, 0, 0, 0, bangkok), holidays)
+
+	// Assert
+	expected := []string{"🌉 วันลาเชื่อมวันหยุด\n- ลา ศุกร์ 6 ธ.ค. หยุดยาว 4 วัน (5–8 ธ.ค.)"}
+	if len(sections) != 1 || sections[0] != expected[0] {
+		t.Errorf("Expected %v, got %v", expected, sections)
+	}
+}
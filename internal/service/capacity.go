@@ -0,0 +1,157 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CapacityPolicy sets the share of a team that must be available on a working day.
+type CapacityPolicy struct {
+	// MinAvailablePercent applies to teams without their own threshold.
+	MinAvailablePercent     float64
+	TeamMinAvailablePercent map[string]float64
+	// LookaheadDays is how far ahead NotifyCapacityOutlook scans; 0 disables the scan.
+	LookaheadDays int
+}
+
+func (p CapacityPolicy) threshold(team string) float64 {
+	if threshold, ok := p.TeamMinAvailablePercent[team]; ok {
+		return threshold
+	}
+	return p.MinAvailablePercent
+}
+
+// teamCapacity is how many of a team's members scheduled for a day are not on leave.
+type teamCapacity struct {
+	team      string
+	scheduled float64
+	available float64
+}
+
+func (c teamCapacity) percent() float64 {
+	return c.available / c.scheduled * 100
+}
+
+// WithCapacityCheck adds a staffing warning to the daily message for every team whose available
+// share, computed from the people directory's teams and working days, falls below the policy.
+func (e EventNotifyService) WithCapacityCheck(people PeopleRepository, policy CapacityPolicy) EventNotifyService {
+	e.peopleRepository = people
+	e.capacityPolicy = policy
+	return e
+}
+
+// capacityWarnings returns a line per team below its threshold on day.
+func (e EventNotifyService) capacityWarnings(day time.Time) ([]string, error) {
+	if e.peopleRepository == nil {
+		return nil, nil
+	}
+	people, err := e.peopleRepository.GetPeople()
+	if err != nil {
+		log.Printf("Error while getting people: %v", err)
+		return nil, fmt.Errorf("Error while getting people: %v", err)
+	}
+	start, end := dailyWindow(day)
+	leaveEvents, err := e.leaveEventRepository.ListEvents(start, end)
+	if err != nil {
+		log.Printf("Error while getting leave events: %v", err)
+		return nil, fmt.Errorf("Error while getting leave events: %v", err)
+	}
+
+	var warnings []string
+	for _, capacity := range e.teamCapacities(day, people, leaveEvents) {
+		threshold := e.capacityPolicy.threshold(capacity.team)
+		if capacity.percent() < threshold {
+			warnings = append(warnings, fmt.Sprintf("- %s: เหลือ %.0f%% (%s/%s คน, เกณฑ์ %.0f%%)", capacity.team, capacity.percent(),
+				formatDays(capacity.available), formatDays(capacity.scheduled), threshold))
+		}
+	}
+	return warnings, nil
+}
+
+func (e EventNotifyService) teamCapacities(day time.Time, people []Person, leaveEvents []Event) []teamCapacity {
+	// Leave adds up over the day, e.g. a morning and an afternoon off make a whole day, and is capped
+	// at one day, counted the same way as in the leave report.
+	midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	onLeave := map[string]float64{}
+	for _, event := range leaveEvents {
+		person, ok := e.peopleRepository.FindPerson(event.Summary)
+		if !ok {
+			log.Printf("Leave event %q does not match anyone in the people directory", event.Summary)
+			continue
+		}
+		onLeave[person.Name] = min(onLeave[person.Name]+leaveDaysOn(event, midnight), 1)
+	}
+
+	byTeam := map[string]*teamCapacity{}
+	var teams []string
+	for _, person := range people {
+		if person.Team == "" || !person.worksOn(day) {
+			continue
+		}
+		capacity, ok := byTeam[person.Team]
+		if !ok {
+			capacity = &teamCapacity{team: person.Team}
+			byTeam[person.Team] = capacity
+			teams = append(teams, person.Team)
+		}
+		capacity.scheduled++
+		capacity.available += 1 - onLeave[person.Name]
+	}
+	sort.Strings(teams)
+
+	var capacities []teamCapacity
+	for _, team := range teams {
+		capacities = append(capacities, *byTeam[team])
+	}
+	return capacities
+}
+
+// NotifyCapacityOutlook scans the working days after asOf within the policy's lookahead and sends
+// an early warning listing the days on which a team falls below its threshold.
+func (e EventNotifyService) NotifyCapacityOutlook(asOf time.Time) error {
+	if e.peopleRepository == nil || e.capacityPolicy.LookaheadDays <= 0 {
+		return nil
+	}
+	from := time.Date(asOf.Year(), asOf.Month(), asOf.Day()+1, 0, 0, 0, 0, asOf.Location())
+	until := from.AddDate(0, 0, e.capacityPolicy.LookaheadDays)
+	holidays, err := e.holidayEventRepository.ListEvents(from, until)
+	if err != nil {
+		log.Printf("Error while getting holiday events: %v", err)
+		return fmt.Errorf("Error while getting holiday events: %v", err)
+	}
+
+	var lines []string
+	for day := from; day.Before(until); day = day.AddDate(0, 0, 1) {
+		if isWeekend(day) || len(eventSummariesBetween(holidays, day, day.AddDate(0, 0, 1))) > 0 {
+			continue
+		}
+		warnings, err := e.capacityWarnings(day)
+		if err != nil {
+			return err
+		}
+		for _, warning := range warnings {
+			lines = append(lines, "- "+day.Format(time.DateOnly)+" "+weekdayTh(day.Weekday())+" "+strings.TrimPrefix(warning, "- "))
+		}
+	}
+	if len(lines) == 0 {
+		log.Printf("All teams are above their capacity threshold for the next %d days", e.capacityPolicy.LookaheadDays)
+		return nil
+	}
+
+	message := fmt.Sprintf("🔭 แจ้งเตือนกำลังคนล่วงหน้า %d วัน\n", e.capacityPolicy.LookaheadDays) + strings.Join(lines, "\n")
+	err = e.notificationRepository.SendNotification(message)
+	if err != nil {
+		log.Printf("Failed to send notification: %v", err)
+		return fmt.Errorf("Error while sending notification: %v", err)
+	}
+	return nil
+}
+
+// formatDays prints whole days without decimals and half-days as .5.
+func formatDays(days float64) string {
+	return strconv.FormatFloat(days, 'f', -1, 64)
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockPeopleRepository struct {
+	people []Person
+}
+
+func (m *MockPeopleRepository) GetPeople() ([]Person, error) {
+	return m.people, nil
+}
+
+func (m *MockPeopleRepository) FindPerson(eventSummary string) (Person, bool) {
+	for _, person := range m.people {
+		if strings.HasPrefix(eventSummary, person.Name) {
+			return person, true
+		}
+	}
+	return Person{}, false
+}
+
+func newCapacityTestPeople() *MockPeopleRepository {
+	return &MockPeopleRepository{people: []Person{
+		{Name: "Alice", Team: "backend"},
+		{Name: "Bob", Team: "backend"},
+		{Name: "Carol", Team: "backend"},
+		{Name: "Dan", Team: "backend", WorkingDays: []time.Weekday{time.Monday, time.Tuesday}},
+		{Name: "Eve", Team: "frontend"},
+		{Name: "Frank", Team: "frontend"},
+	}}
+}
+
+var testCapacityPolicy = CapacityPolicy{
+	MinAvailablePercent:     50,
+	TeamMinAvailablePercent: map[string]float64{"backend": 60},
+	LookaheadDays:           3,
+}
+
+func TestEventNotifyService_Notify_CapacityWarning(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	wednesday := time.Date(2025, 8, 13, 0, 0, 0, 0, bangkok)
+	leaveRepo := &MockEventRepository{
+		events: []string{"Alice", "Bob (afternoon)"},
+		listed: []Event{
+			allDay("Alice", wednesday, 1),
+			{Summary: "Bob (afternoon)", Start: wednesday.Add(13 * time.Hour), End: wednesday.Add(18 * time.Hour)},
+		},
+	}
+	mockNotification := &MockNotificationRepository{}
+	service := NewEventNotifyService(leaveRepo, &MockEventRepository{}, &MockEventRepository{}, mockNotification).
+		WithCapacityCheck(newCapacityTestPeople(), testCapacityPolicy)
+
+	// Act
+	err := service.Notify(wednesday.Add(8 * time.Hour))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// Dan works part-time and is not scheduled on Wednesdays, so backend has 1.5 of 3 people.
+	expectedMessage := "📅 วันนี้ใครลา : (2025-08-13)\n- Alice\n- Bob (afternoon)\n\n" +
+		"⚠️ กำลังคนต่ำกว่าเกณฑ์ : (2025-08-13)\n- backend: เหลือ 50% (1.5/3 คน, เกณฑ์ 60%)"
+	if mockNotification.sentMessage != expectedMessage {
+		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
+	}
+}
+
+func TestEventNotifyService_TeamCapacities_HalfDaysAddUp(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	wednesday := time.Date(2025, 8, 13, 0, 0, 0, 0, bangkok)
+	people := newCapacityTestPeople()
+	service := NewEventNotifyService(&MockEventRepository{}, &MockEventRepository{}, &MockEventRepository{}, &MockNotificationRepository{}).
+		WithCapacityCheck(people, testCapacityPolicy)
+	leaveEvents := []Event{
+		{Summary: "Bob (morning)", Start: wednesday.Add(9 * time.Hour), End: wednesday.Add(12 * time.Hour)},
+		{Summary: "Bob (afternoon)", Start: wednesday.Add(13 * time.Hour), End: wednesday.Add(18 * time.Hour)},
+	}
+
+	// Act
+	capacities := service.teamCapacities(wednesday.Add(8*time.Hour), people.people, leaveEvents)
+
+	// Assert
+	if len(capacities) != 2 || capacities[0].team != "backend" || capacities[0].available != 2 || capacities[0].scheduled != 3 {
+		t.Errorf("Expected Bob to be away the whole day, leaving backend 2 of 3, got %+v", capacities)
+	}
+}
+
+func TestEventNotifyService_Notify_CapacityAboveThreshold(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	tuesday := time.Date(2025, 8, 12, 0, 0, 0, 0, bangkok)
+	leaveRepo := &MockEventRepository{events: []string{"Alice"}, listed: []Event{allDay("Alice", tuesday, 1)}}
+	mockNotification := &MockNotificationRepository{}
+	service := NewEventNotifyService(leaveRepo, &MockEventRepository{}, &MockEventRepository{}, mockNotification).
+		WithCapacityCheck(newCapacityTestPeople(), testCapacityPolicy)
+
+	// Act
+	err := service.Notify(tuesday.Add(8 * time.Hour))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedMessage := "📅 วันนี้ใครลา : (2025-08-12)\n- Alice"
+	if mockNotification.sentMessage != expectedMessage {
+		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
+	}
+}
+
+func TestEventNotifyService_NotifyCapacityOutlook(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	tuesday := time.Date(2025, 8, 12, 0, 0, 0, 0, bangkok)
+	leaveRepo := &MockEventRepository{listed: []Event{
+		allDay("Alice", tuesday, 3),
+		allDay("Eve", tuesday, 1),
+		{Summary: "Bob (afternoon)", Start: tuesday.AddDate(0, 0, 1).Add(13 * time.Hour), End: tuesday.AddDate(0, 0, 1).Add(18 * time.Hour)},
+	}}
+	holidayRepo := &MockEventRepository{listed: []Event{allDay("วันแม่แห่งชาติ", tuesday, 1)}}
+	mockNotification := &MockNotificationRepository{}
+	service := NewEventNotifyService(leaveRepo, holidayRepo, &MockEventRepository{}, mockNotification).
+		WithCapacityCheck(newCapacityTestPeople(), testCapacityPolicy)
+
+	// Act
+	err := service.NotifyCapacityOutlook(time.Date(2025, 8, 11, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// Eve's leave falls on the holiday, which is skipped.
+	expectedMessage := "🔭 แจ้งเตือนกำลังคนล่วงหน้า 3 วัน\n- 2025-08-13 พุธ backend: เหลือ 50% (1.5/3 คน, เกณฑ์ 60%)"
+	if mockNotification.sentMessage != expectedMessage {
+		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
+	}
+}
+
+func TestEventNotifyService_NotifyCapacityOutlook_Disabled(t *testing.T) {
+	// Arrange
+	mockNotification := &MockNotificationRepository{}
+	service := NewEventNotifyService(&MockEventRepository{}, &MockEventRepository{}, &MockEventRepository{}, mockNotification)
+
+	// Act
+	err := service.NotifyCapacityOutlook(time.Date(2025, 8, 11, 8, 0, 0, 0, time.UTC))
+
+	// Assert
+	if err != nil || mockNotification.numberOfCalls != 0 {
+		t.Errorf("Expected nothing to happen, got %v and %d notifications", err, mockNotification.numberOfCalls)
+	}
+}
@@ -5,4 +5,35 @@ import "time"
 type EventRepository interface {
 	GetEvents(asOf time.Time) ([]string, error)
 	GetEventsBetween(start, end time.Time) ([]string, error)
+	// ListEvents returns the events overlapping [start, end) ordered by start time.
+	ListEvents(start, end time.Time) ([]Event, error)
+}
+
+// Event is a calendar event with its time range, in the location of the query. End is exclusive,
+// so an all-day event on a single date ends at midnight of the next day.
+type Event struct {
+	Summary string
+	Start   time.Time
+	End     time.Time
+	AllDay  bool
+}
+
+// dailyWindow is the part of day that counts for the daily roster, the same 09:00 start the
+// calendar sources use for GetEvents, so a shift handed over in the morning counts for the new assignee.
+func dailyWindow(day time.Time) (time.Time, time.Time) {
+	start := time.Date(day.Year(), day.Month(), day.Day(), 9, 0, 0, 0, day.Location())
+	end := time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, day.Location())
+	return start, end
+}
+
+// halfDayMaxDuration is the longest timed leave that counts as half a day; a morning (09:00–12:00)
+// or an afternoon (13:00–18:00) off fits, a whole working day does not.
+const halfDayMaxDuration = 5 * time.Hour
+
+// leaveDays is how much of a working day a leave event takes: 0.5 for a half-day, otherwise 1.
+func leaveDays(event Event) float64 {
+	if !event.AllDay && event.End.Sub(event.Start) <= halfDayMaxDuration {
+		return 0.5
+	}
+	return 1
 }
@@ -3,7 +3,7 @@ package service
 import (
 	"fmt"
 	"log"
-	"strconv"
+	"strings"
 	"time"
 )
 
@@ -12,6 +12,13 @@ type EventNotifyService struct {
 	holidayEventRepository EventRepository
 	onCallEventRepository  EventRepository
 	notificationRepository NotificationRepository
+	// peopleRepository and capacityPolicy enable the staffing warnings; see WithCapacityCheck.
+	peopleRepository PeopleRepository
+	capacityPolicy   CapacityPolicy
+	// onCallRequired shows a warning instead of leaving out the on-call list when nobody is on call.
+	onCallRequired bool
+	// nextWorkingDayTemplate holds the headings of the evening message; see WithNextWorkingDayTemplate.
+	nextWorkingDayTemplate NextWorkingDayTemplate
 }
 
 func NewEventNotifyService(leaveEventRepo, holidayEventRepo, onCallEventRepo EventRepository,
@@ -24,38 +31,17 @@ func NewEventNotifyService(leaveEventRepo, holidayEventRepo, onCallEventRepo Eve
 	}
 }
 
+// WithOnCallRequired makes the daily message warn when nobody is on call for the day.
+func (e EventNotifyService) WithOnCallRequired() EventNotifyService {
+	e.onCallRequired = true
+	return e
+}
+
 func (e EventNotifyService) Notify(asOf time.Time) error {
 	if isEndOfMonth(asOf) {
 		nextDay := asOf.AddDate(0, 0, 1)
-		lastDayOfMonth := time.Date(nextDay.Year(), nextDay.Month()+1, 0, 0, 0, 0, 0, nextDay.Location())
-		holidaysNextMonth, err := e.holidayEventRepository.GetEventsBetween(nextDay, lastDayOfMonth)
-		if err != nil {
-			log.Printf("Error while getting holiday events: %v", err)
-			return fmt.Errorf("Error while getting holiday events: %v", err)
-		}
-		if len(holidaysNextMonth) > 0 {
-			log.Println("There are " + strconv.Itoa(len(holidaysNextMonth)) + " holidays next month")
-			message := fmt.Sprintf("มีวันหยุด %d วันเดือน %s 🎉🏖️:\n", len(holidaysNextMonth), monthEnToTh(lastDayOfMonth.Format("January")))
-			for i, event := range holidaysNextMonth {
-				if i == len(holidaysNextMonth)-1 {
-					message += fmt.Sprintf("%v", "- "+event)
-				} else {
-					message += fmt.Sprintf("%v\n", "- "+event)
-				}
-			}
-			err = e.notificationRepository.SendNotification(message)
-			if err != nil {
-				log.Printf("Error while sending notification: %v", err)
-				return fmt.Errorf("Error while sending notification: %v", err)
-			}
-		} else {
-			log.Println("There are no holidays next month")
-			message := fmt.Sprintf("เดือน %s ไม่มีวันหยุด 💪😢", monthEnToTh(lastDayOfMonth.Format("January")))
-			err = e.notificationRepository.SendNotification(message)
-			if err != nil {
-				log.Printf("Error while sending notification: %v", err)
-				return fmt.Errorf("Error while sending notification: %v", err)
-			}
+		if err := e.announceHolidays(time.Date(nextDay.Year(), nextDay.Month(), 1, 0, 0, 0, 0, nextDay.Location())); err != nil {
+			return err
 		}
 	}
 
@@ -72,6 +58,11 @@ func (e EventNotifyService) Notify(asOf time.Time) error {
 		return fmt.Errorf("Error while getting holiday events: %v", err)
 	}
 
+	if len(onCallEvents) == 0 && e.onCallRequired {
+		log.Println("Nobody is on call on " + asOf.Format(time.DateOnly))
+		onCallEvents = []string{noOnCallAssigned}
+	}
+
 	isWeekend := asOf.Weekday() == time.Saturday || asOf.Weekday() == time.Sunday
 
 	if len(holidayEvents) > 0 || isWeekend {
@@ -117,8 +108,12 @@ func (e EventNotifyService) Notify(asOf time.Time) error {
 		if err != nil {
 			return fmt.Errorf("Error while getting events: %v", err)
 		}
+		capacityWarnings, err := e.capacityWarnings(asOf)
+		if err != nil {
+			return err
+		}
 
-		if len(leaveEvents) > 0 || len(onCallEvents) > 0 {
+		if len(leaveEvents) > 0 || len(onCallEvents) > 0 || len(capacityWarnings) > 0 {
 			message := ""
 
 			if len(leaveEvents) > 0 {
@@ -148,6 +143,14 @@ func (e EventNotifyService) Notify(asOf time.Time) error {
 				}
 			}
 
+			if len(capacityWarnings) > 0 {
+				log.Printf("There are %d teams below their capacity threshold today.", len(capacityWarnings))
+				if message != "" {
+					message += "\n\n"
+				}
+				message += fmt.Sprintf("⚠️ กำลังคนต่ำกว่าเกณฑ์ : (%s)\n", asOf.Format(time.DateOnly)) + strings.Join(capacityWarnings, "\n")
+			}
+
 			err = e.notificationRepository.SendNotification(message)
 			if err != nil {
 				log.Printf("Failed to send notification: %v", err)
@@ -194,3 +197,22 @@ func monthEnToTh(monthEn string) string {
 		return monthEn
 	}
 }
+
+func weekdayTh(weekday time.Weekday) string {
+	switch weekday {
+	case time.Sunday:
+		return "อาทิตย์"
+	case time.Monday:
+		return "จันทร์"
+	case time.Tuesday:
+		return "อังคาร"
+	case time.Wednesday:
+		return "พุธ"
+	case time.Thursday:
+		return "พฤหัสบดี"
+	case time.Friday:
+		return "ศุกร์"
+	default:
+		return "เสาร์"
+	}
+}
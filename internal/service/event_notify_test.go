@@ -10,6 +10,7 @@ import (
 type MockEventRepository struct {
 	events        []string
 	eventsBetween []string
+	listed        []Event
 	err           error
 }
 
@@ -27,6 +28,20 @@ func (m *MockEventRepository) GetEventsBetween(start, end time.Time) ([]string,
 	return m.eventsBetween, nil
 }
 
+// ListEvents returns the listed events overlapping [start, end), like the calendar sources do.
+func (m *MockEventRepository) ListEvents(start, end time.Time) ([]Event, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var events []Event
+	for _, event := range m.listed {
+		if event.Start.Before(end) && event.End.After(start) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
 type MockNotificationRepository struct {
 	numberOfCalls int
 	sentMessage   string
@@ -558,9 +573,13 @@ func TestIsEndOfMonth_AllMonths(t *testing.T) {
 func TestEventNotifyService_Notify_EndOfMonth_WithHolidays(t *testing.T) {
 	// Arrange
 	mockNotification := &MockNotificationRepository{}
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
 	mockHolidayRepo := &MockEventRepository{
-		events:        []string{},
-		eventsBetween: []string{"New Year's Day", "Independence Day"},
+		events: []string{},
+		listed: []Event{
+			allDay("Chinese New Year", time.Date(2025, 2, 1, 0, 0, 0, 0, bangkok), 1),
+			allDay("วันมาฆบูชา", time.Date(2025, 2, 12, 0, 0, 0, 0, bangkok), 1),
+		},
 	}
 	mockLeaveRepo := &MockEventRepository{events: []string{}}
 	mockOnCallRepo := &MockEventRepository{events: []string{}}
@@ -568,7 +587,6 @@ func TestEventNotifyService_Notify_EndOfMonth_WithHolidays(t *testing.T) {
 	service := NewEventNotifyService(mockLeaveRepo, mockHolidayRepo, mockOnCallRepo, mockNotification)
 
 	// Create end of month date: January 31, 2025, 8 AM Bangkok time
-	bangkok, _ := time.LoadLocation("Asia/Bangkok")
 	testDate := time.Date(2025, 1, 31, 8, 0, 0, 0, bangkok)
 
 	// Act
@@ -583,7 +601,7 @@ func TestEventNotifyService_Notify_EndOfMonth_WithHolidays(t *testing.T) {
 		t.Errorf("Expected notification to be called once, got %d", mockNotification.numberOfCalls)
 	}
 
-	expectedMessage := "มีวันหยุด 2 วันเดือน กุมภาพันธ์ 🎉🏖️:\n- New Year's Day\n- Independence Day"
+	expectedMessage := "มีวันหยุด 2 วันเดือน กุมภาพันธ์ 🎉🏖️:\n- เสาร์ 1 ก.พ.: Chinese New Year (ตรงกับวันเสาร์)\n- พุธ 12 ก.พ.: วันมาฆบูชา"
 	if mockNotification.sentMessage != expectedMessage {
 		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
 	}
@@ -623,7 +641,7 @@ func TestEventNotifyService_Notify_EndOfMonth_NoHolidays(t *testing.T) {
 	}
 }
 
-func TestEventNotifyService_Notify_EndOfMonth_GetEventsBetweenError(t *testing.T) {
+func TestEventNotifyService_Notify_EndOfMonth_ListEventsError(t *testing.T) {
 	// Arrange
 	mockNotification := &MockNotificationRepository{}
 	mockHolidayRepo := &MockEventRepository{
@@ -660,9 +678,10 @@ func TestEventNotifyService_Notify_EndOfMonth_GetEventsBetweenError(t *testing.T
 func TestEventNotifyService_Notify_EndOfMonth_SendNotificationErrorWithHolidays(t *testing.T) {
 	// Arrange
 	mockNotification := &MockNotificationRepository{err: errors.New("notification error")}
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
 	mockHolidayRepo := &MockEventRepository{
-		events:        []string{},
-		eventsBetween: []string{"Holiday 1"},
+		events: []string{},
+		listed: []Event{allDay("Holiday 1", time.Date(2025, 3, 5, 0, 0, 0, 0, bangkok), 1)},
 	}
 	mockLeaveRepo := &MockEventRepository{events: []string{}}
 	mockOnCallRepo := &MockEventRepository{events: []string{}}
@@ -670,7 +689,6 @@ func TestEventNotifyService_Notify_EndOfMonth_SendNotificationErrorWithHolidays(
 	service := NewEventNotifyService(mockLeaveRepo, mockHolidayRepo, mockOnCallRepo, mockNotification)
 
 	// Create end of month date: February 28, 2025, 8 AM Bangkok time
-	bangkok, _ := time.LoadLocation("Asia/Bangkok")
 	testDate := time.Date(2025, 2, 28, 8, 0, 0, 0, bangkok)
 
 	// Act
@@ -728,9 +746,10 @@ func TestEventNotifyService_Notify_EndOfMonth_SendNotificationErrorNoHolidays(t
 func TestEventNotifyService_Notify_EndOfMonth_FebruaryLeapYear(t *testing.T) {
 	// Arrange
 	mockNotification := &MockNotificationRepository{}
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
 	mockHolidayRepo := &MockEventRepository{
-		events:        []string{},
-		eventsBetween: []string{"Spring Festival"},
+		events: []string{},
+		listed: []Event{allDay("Spring Festival", time.Date(2024, 3, 6, 0, 0, 0, 0, bangkok), 1)},
 	}
 	mockLeaveRepo := &MockEventRepository{events: []string{}}
 	mockOnCallRepo := &MockEventRepository{events: []string{}}
@@ -738,7 +757,6 @@ func TestEventNotifyService_Notify_EndOfMonth_FebruaryLeapYear(t *testing.T) {
 	service := NewEventNotifyService(mockLeaveRepo, mockHolidayRepo, mockOnCallRepo, mockNotification)
 
 	// Create end of month date: February 29, 2024 (leap year), 8 AM Bangkok time
-	bangkok, _ := time.LoadLocation("Asia/Bangkok")
 	testDate := time.Date(2024, 2, 29, 8, 0, 0, 0, bangkok)
 
 	// Act
@@ -753,7 +771,7 @@ func TestEventNotifyService_Notify_EndOfMonth_FebruaryLeapYear(t *testing.T) {
 		t.Errorf("Expected notification to be called once, got %d", mockNotification.numberOfCalls)
 	}
 
-	expectedMessage := "มีวันหยุด 1 วันเดือน มีนาคม 🎉🏖️:\n- Spring Festival"
+	expectedMessage := "มีวันหยุด 1 วันเดือน มีนาคม 🎉🏖️:\n- พุธ 6 มี.ค.: Spring Festival"
 	if mockNotification.sentMessage != expectedMessage {
 		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
 	}
@@ -762,9 +780,10 @@ func TestEventNotifyService_Notify_EndOfMonth_FebruaryLeapYear(t *testing.T) {
 func TestEventNotifyService_Notify_EndOfMonth_SingleHoliday(t *testing.T) {
 	// Arrange
 	mockNotification := &MockNotificationRepository{}
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
 	mockHolidayRepo := &MockEventRepository{
-		events:        []string{},
-		eventsBetween: []string{"Labor Day"},
+		events: []string{},
+		listed: []Event{allDay("วันเฉลิมพระชนมพรรษาพระราชินี", time.Date(2025, 6, 3, 0, 0, 0, 0, bangkok), 1)},
 	}
 	mockLeaveRepo := &MockEventRepository{events: []string{}}
 	mockOnCallRepo := &MockEventRepository{events: []string{}}
@@ -772,7 +791,6 @@ func TestEventNotifyService_Notify_EndOfMonth_SingleHoliday(t *testing.T) {
 	service := NewEventNotifyService(mockLeaveRepo, mockHolidayRepo, mockOnCallRepo, mockNotification)
 
 	// Create end of month date: May 31, 2025, 8 AM Bangkok time
-	bangkok, _ := time.LoadLocation("Asia/Bangkok")
 	testDate := time.Date(2025, 5, 31, 8, 0, 0, 0, bangkok)
 
 	// Act
@@ -787,7 +805,9 @@ func TestEventNotifyService_Notify_EndOfMonth_SingleHoliday(t *testing.T) {
 		t.Errorf("Expected notification to be called once, got %d", mockNotification.numberOfCalls)
 	}
 
-	expectedMessage := "มีวันหยุด 1 วันเดือน มิถุนายน 🎉🏖️:\n- Labor Day"
+	// The bridge day joins the weekend at the end of May.
+	expectedMessage := "มีวันหยุด 1 วันเดือน มิถุนายน 🎉🏖️:\n- อังคาร 3 มิ.ย.: วันเฉลิมพระชนมพรรษาพระราชินี\n\n" +
+		"🌉 วันลาเชื่อมวันหยุด\n- ลา จันทร์ 2 มิ.ย. หยุดยาว 4 วัน (31 พ.ค.–3 มิ.ย.)"
 	if mockNotification.sentMessage != expectedMessage {
 		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
 	}
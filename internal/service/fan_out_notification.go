@@ -0,0 +1,99 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+)
+
+type NotificationPolicy int
+
+const (
+	// NotificationRequired fails the run when the channel cannot be notified.
+	NotificationRequired NotificationPolicy = iota
+	// NotificationBestEffort only logs a failure to notify the channel.
+	NotificationBestEffort
+)
+
+func (p NotificationPolicy) String() string {
+	if p == NotificationBestEffort {
+		return "best-effort"
+	}
+	return "required"
+}
+
+type NotificationChannel struct {
+	Name       string
+	Repository NotificationRepository
+	Policy     NotificationPolicy
+}
+
+// FanOutNotificationRepository sends every notification to all channels concurrently.
+type FanOutNotificationRepository struct {
+	channels []NotificationChannel
+}
+
+func NewFanOutNotificationRepository(channels ...NotificationChannel) FanOutNotificationRepository {
+	return FanOutNotificationRepository{channels: channels}
+}
+
+// ChannelError lists every channel that failed during a fan-out send.
+type ChannelError struct {
+	Failures []ChannelFailure
+}
+
+type ChannelFailure struct {
+	Channel string
+	Policy  NotificationPolicy
+	Err     error
+}
+
+func (c ChannelError) Error() string {
+	var failures []string
+	for _, f := range c.Failures {
+		failures = append(failures, fmt.Sprintf("%s (%s): %v", f.Channel, f.Policy, f.Err))
+	}
+	return "failed to notify channels: " + strings.Join(failures, "; ")
+}
+
+func (c ChannelError) Unwrap() []error {
+	var errs []error
+	for _, f := range c.Failures {
+		errs = append(errs, f.Err)
+	}
+	return errs
+}
+
+// SendNotification returns a ChannelError naming every failed channel when at least one
+// required channel failed or when no channel was notified at all. Otherwise best-effort failures
+// are only logged and do not fail the run.
+func (f FanOutNotificationRepository) SendNotification(message string) error {
+	errs := make([]error, len(f.channels))
+	var wg sync.WaitGroup
+	for i, channel := range f.channels {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = channel.Repository.SendNotification(message)
+		}()
+	}
+	wg.Wait()
+
+	var failures []ChannelFailure
+	requiredFailed := false
+	for i, channel := range f.channels {
+		if errs[i] == nil {
+			continue
+		}
+		log.Printf("Failed to notify %s channel %s: %v", channel.Policy, channel.Name, errs[i])
+		failures = append(failures, ChannelFailure{Channel: channel.Name, Policy: channel.Policy, Err: errs[i]})
+		if channel.Policy == NotificationRequired {
+			requiredFailed = true
+		}
+	}
+	if requiredFailed || len(failures) == len(f.channels) {
+		return ChannelError{Failures: failures}
+	}
+	return nil
+}
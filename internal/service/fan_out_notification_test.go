@@ -0,0 +1,97 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+// ConcurrentMockNotificationRepository is safe to use from the fan-out goroutines.
+type ConcurrentMockNotificationRepository struct {
+	mu          sync.Mutex
+	sentMessage string
+	err         error
+}
+
+func (m *ConcurrentMockNotificationRepository) SendNotification(message string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sentMessage = message
+	return m.err
+}
+
+func TestFanOutNotificationRepository_SendNotification_AllChannelsSucceed(t *testing.T) {
+	// Arrange
+	line := &ConcurrentMockNotificationRepository{}
+	slack := &ConcurrentMockNotificationRepository{}
+	fanOut := NewFanOutNotificationRepository(
+		NotificationChannel{Name: "line", Repository: line, Policy: NotificationRequired},
+		NotificationChannel{Name: "slack", Repository: slack, Policy: NotificationBestEffort},
+	)
+
+	// Act
+	err := fanOut.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- John Doe")
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if line.sentMessage != "📅 วันนี้ใครลา : (2025-08-12)\n- John Doe" || slack.sentMessage != line.sentMessage {
+		t.Errorf("Expected every channel to receive the message, got '%s' and '%s'", line.sentMessage, slack.sentMessage)
+	}
+}
+
+func TestFanOutNotificationRepository_SendNotification_BestEffortFailureIsIgnored(t *testing.T) {
+	// Arrange
+	fanOut := NewFanOutNotificationRepository(
+		NotificationChannel{Name: "line", Repository: &ConcurrentMockNotificationRepository{}, Policy: NotificationRequired},
+		NotificationChannel{Name: "email", Repository: &ConcurrentMockNotificationRepository{err: errors.New("smtp down")}, Policy: NotificationBestEffort},
+	)
+
+	// Act
+	err := fanOut.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- John Doe")
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestFanOutNotificationRepository_SendNotification_FailsWhenNothingWasDelivered(t *testing.T) {
+	// Arrange
+	fanOut := NewFanOutNotificationRepository(
+		NotificationChannel{Name: "slack", Repository: &ConcurrentMockNotificationRepository{err: errors.New("slack down")}, Policy: NotificationBestEffort},
+		NotificationChannel{Name: "email", Repository: &ConcurrentMockNotificationRepository{err: errors.New("smtp down")}, Policy: NotificationBestEffort},
+	)
+
+	// Act
+	err := fanOut.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- John Doe")
+
+	// Assert
+	var channelErr ChannelError
+	if !errors.As(err, &channelErr) || len(channelErr.Failures) != 2 {
+		t.Errorf("Expected a ChannelError listing both channels, got %v", err)
+	}
+}
+
+func TestFanOutNotificationRepository_SendNotification_RequiredFailureListsEveryFailedChannel(t *testing.T) {
+	// Arrange
+	lineErr := errors.New("line down")
+	fanOut := NewFanOutNotificationRepository(
+		NotificationChannel{Name: "line", Repository: &ConcurrentMockNotificationRepository{err: lineErr}, Policy: NotificationRequired},
+		NotificationChannel{Name: "teams", Repository: &ConcurrentMockNotificationRepository{}, Policy: NotificationRequired},
+		NotificationChannel{Name: "email", Repository: &ConcurrentMockNotificationRepository{err: errors.New("smtp down")}, Policy: NotificationBestEffort},
+	)
+
+	// Act
+	err := fanOut.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- John Doe")
+
+	// Assert
+	expectedError := "failed to notify channels: line (required): line down; email (best-effort): smtp down"
+	if err == nil || err.Error() != expectedError {
+		t.Errorf("Expected error '%s', got '%v'", expectedError, err)
+	}
+	if !errors.Is(err, lineErr) {
+		t.Errorf("Expected error to wrap the channel error")
+	}
+}
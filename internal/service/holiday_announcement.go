@@ -0,0 +1,117 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// holidayRun is a run of consecutive days, first..last inclusive, with the same holidays.
+type holidayRun struct {
+	first time.Time
+	last  time.Time
+	names []string
+}
+
+// announceHolidays sends the holidays of the month starting at monthStart, followed by its long
+// breaks and bridge days.
+func (e EventNotifyService) announceHolidays(monthStart time.Time) error {
+	month := monthEnToTh(monthStart.Format("January"))
+	// A week either side of the month keeps the breaks across its edges whole.
+	holidays, err := e.holidayEventRepository.ListEvents(monthStart.AddDate(0, 0, -7), monthStart.AddDate(0, 1, 7))
+	if err != nil {
+		log.Printf("Error while getting holiday events: %v", err)
+		return fmt.Errorf("Error while getting holiday events: %v", err)
+	}
+
+	runs, days := holidayRuns(monthStart, holidays)
+	var message string
+	if days == 0 {
+		log.Println("There are no holidays next month")
+		message = fmt.Sprintf("เดือน %s ไม่มีวันหยุด 💪😢", month)
+	} else {
+		log.Printf("There are %d holidays next month", days)
+		var lines []string
+		for _, run := range runs {
+			lines = append(lines, "- "+formatHolidayRun(run))
+		}
+		message = fmt.Sprintf("มีวันหยุด %d วันเดือน %s 🎉🏖️:\n", days, month) + strings.Join(lines, "\n")
+		if sections := longBreakSections(monthStart, holidays); len(sections) > 0 {
+			message += "\n\n" + strings.Join(sections, "\n\n")
+		}
+	}
+
+	err = e.notificationRepository.SendNotification(message)
+	if err != nil {
+		log.Printf("Error while sending notification: %v", err)
+		return fmt.Errorf("Error while sending notification: %v", err)
+	}
+	return nil
+}
+
+// holidayRuns groups the holidays of the month starting at monthStart into runs of consecutive
+// days, such as the three days of Songkran, and counts the days that are holidays.
+func holidayRuns(monthStart time.Time, holidays []Event) ([]holidayRun, int) {
+	var runs []holidayRun
+	days := 0
+	for day := monthStart; day.Before(monthStart.AddDate(0, 1, 0)); day = day.AddDate(0, 0, 1) {
+		names := eventSummariesBetween(holidays, day, day.AddDate(0, 0, 1))
+		if len(names) == 0 {
+			continue
+		}
+		days++
+		if n := len(runs); n > 0 && sameDate(runs[n-1].last.AddDate(0, 0, 1), day) &&
+			strings.Join(runs[n-1].names, ", ") == strings.Join(names, ", ") {
+			runs[n-1].last = day
+			continue
+		}
+		runs = append(runs, holidayRun{first: day, last: day, names: names})
+	}
+	return runs, days
+}
+
+// formatHolidayRun shows a single day as "อังคาร 12 ส.ค.: name" and a run as
+// "13–15 เม.ย. (3 วัน): name", marking the weekend days it falls on.
+func formatHolidayRun(run holidayRun) string {
+	dates := weekdayTh(run.first.Weekday()) + " " + formatThaiDateRange(run.first, run.last)
+	if !sameDate(run.first, run.last) {
+		dates = fmt.Sprintf("%s (%d วัน)", formatThaiDateRange(run.first, run.last), daysBetween(run.first, run.last)+1)
+	}
+	line := dates + ": " + strings.Join(run.names, ", ")
+
+	var weekend []string
+	for _, weekday := range []time.Weekday{time.Saturday, time.Sunday} {
+		for day := run.first; !day.After(run.last); day = day.AddDate(0, 0, 1) {
+			if day.Weekday() == weekday {
+				weekend = append(weekend, weekdayTh(weekday))
+				break
+			}
+		}
+	}
+	if len(weekend) > 0 {
+		line += " (ตรงกับวัน" + strings.Join(weekend, "และ") + ")"
+	}
+	return line
+}
+
+// formatThaiDateRange shows dates the way they are written in Thai: "12 ส.ค.", "13–15 เม.ย." or
+// "30 เม.ย.–2 พ.ค.".
+func formatThaiDateRange(first, last time.Time) string {
+	switch {
+	case sameDate(first, last):
+		return formatThaiDate(first)
+	case first.Year() == last.Year() && first.Month() == last.Month():
+		return fmt.Sprintf("%d–%s", first.Day(), formatThaiDate(last))
+	default:
+		return formatThaiDate(first) + "–" + formatThaiDate(last)
+	}
+}
+
+func formatThaiDate(day time.Time) string {
+	return fmt.Sprintf("%d %s", day.Day(), monthShortTh(day.Month()))
+}
+
+func monthShortTh(month time.Month) string {
+	return [...]string{"ม.ค.", "ก.พ.", "มี.ค.", "เม.ย.", "พ.ค.", "มิ.ย.", "ก.ค.", "ส.ค.", "ก.ย.", "ต.ค.", "พ.ย.", "ธ.ค."}[month-1]
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventNotifyService_Notify_EndOfMonth_GroupsConsecutiveHolidays(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	mockNotification := &MockNotificationRepository{}
+	mockHolidayRepo := &MockEventRepository{listed: []Event{
+		allDay("วันจักรี", time.Date(2025, 4, 6, 0, 0, 0, 0, bangkok), 1),
+		allDay("วันหยุดชดเชยวันจักรี", time.Date(2025, 4, 7, 0, 0, 0, 0, bangkok), 1),
+		allDay("วันสงกรานต์", time.Date(2025, 4, 13, 0, 0, 0, 0, bangkok), 1),
+		allDay("วันสงกรานต์", time.Date(2025, 4, 14, 0, 0, 0, 0, bangkok), 1),
+		allDay("วันสงกรานต์", time.Date(2025, 4, 15, 0, 0, 0, 0, bangkok), 1),
+		allDay("วันหยุดชดเชยวันสงกรานต์", time.Date(2025, 4, 16, 0, 0, 0, 0, bangkok), 1),
+	}}
+	service := NewEventNotifyService(&MockEventRepository{}, mockHolidayRepo, &MockEventRepository{}, mockNotification)
+
+	// Act
+	err := service.Notify(time.Date(2025, 3, 31, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedMessage := "มีวันหยุด 6 วันเดือน เมษายน 🎉🏖️:\n" +
+		"- อาทิตย์ 6 เม.ย.: วันจักรี (ตรงกับวันอาทิตย์)\n" +
+		"- จันทร์ 7 เม.ย.: วันหยุดชดเชยวันจักรี\n" +
+		"- 13–15 เม.ย. (3 วัน): วันสงกรานต์ (ตรงกับวันอาทิตย์)\n" +
+		"- พุธ 16 เม.ย.: วันหยุดชดเชยวันสงกรานต์\n\n" +
+		"🏖️ ช่วงวันหยุดยาว\n" +
+		"- 5–7 เม.ย. (3 วัน)\n" +
+		"- 12–16 เม.ย. (5 วัน)"
+	if mockNotification.sentMessage != expectedMessage {
+		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
+	}
+}
+
+func TestEventNotifyService_Notify_EndOfMonth_TimedHoliday(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	mockNotification := &MockNotificationRepository{}
+	mockHolidayRepo := &MockEventRepository{listed: []Event{
+		{Summary: "Company outing", Start: time.Date(2025, 10, 8, 13, 0, 0, 0, bangkok), End: time.Date(2025, 10, 8, 18, 0, 0, 0, bangkok)},
+	}}
+	service := NewEventNotifyService(&MockEventRepository{}, mockHolidayRepo, &MockEventRepository{}, mockNotification)
+
+	// Act
+	err := service.Notify(time.Date(2025, 9, 30, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedMessage := "มีวันหยุด 1 วันเดือน ตุลาคม 🎉🏖️:\n- พุธ 8 ต.ค.: Company outing"
+	if mockNotification.sentMessage != expectedMessage {
+		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
+	}
+}
+
+func TestFormatThaiDateRange(t *testing.T) {
+	cases := []struct {
+		first, last time.Time
+		expected    string
+	}{
+		{time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC), time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC), "12 ส.ค."},
+		{time.Date(2025, 4, 13, 0, 0, 0, 0, time.UTC), time.Date(2025, 4, 15, 0, 0, 0, 0, time.UTC), "13–15 เม.ย."},
+		{time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), "31 ธ.ค.–2 ม.ค."},
+	}
+	for _, c := range cases {
+		if got := formatThaiDateRange(c.first, c.last); got != c.expected {
+			t.Errorf("formatThaiDateRange(%s, %s) = %q, expected %q", c.first.Format(time.DateOnly), c.last.Format(time.DateOnly), got, c.expected)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// otherLeaveType is the type of leave events matching none of the configured leave types.
+const otherLeaveType = "อื่นๆ"
+
+// LeaveType classifies leave events by keywords in their summary, e.g. "Alice ลาป่วย" or "Bob (sick)".
+type LeaveType struct {
+	Name     string
+	Keywords []string
+}
+
+// DefaultLeaveTypes are the leave types used when none are configured.
+var DefaultLeaveTypes = []LeaveType{
+	{Name: "ลาป่วย", Keywords: []string{"ป่วย", "sick"}},
+	{Name: "ลาพักร้อน", Keywords: []string{"พักร้อน", "vacation", "annual"}},
+	{Name: "ลากิจ", Keywords: []string{"กิจ", "personal"}},
+}
+
+// LeaveReportService reports the leave taken in a month, per person, per leave type and per team,
+// counted in working days.
+type LeaveReportService struct {
+	leaveEventRepository   EventRepository
+	holidayEventRepository EventRepository
+	// peopleRepository maps leave events to people and their teams; without it the part of the
+	// summary before a " (" or " - " is taken as the name and there are no team totals.
+	peopleRepository       PeopleRepository
+	notificationRepository NotificationRepository
+	leaveTypes             []LeaveType
+	// reportStore keeps the CSV export of every report sent, when set.
+	reportStore LeaveReportStore
+}
+
+// LeaveReportStore keeps the CSV exports of the leave reports, e.g. in a directory or an S3 bucket.
+type LeaveReportStore interface {
+	// SaveLeaveReport stores csv under name, replacing an earlier export of the same name.
+	SaveLeaveReport(name string, csv []byte) error
+}
+
+func NewLeaveReportService(leaveEventRepo, holidayEventRepo EventRepository, people PeopleRepository,
+	notificationRepo NotificationRepository, leaveTypes []LeaveType) LeaveReportService {
+	if len(leaveTypes) == 0 {
+		leaveTypes = DefaultLeaveTypes
+	}
+	return LeaveReportService{
+		leaveEventRepository:   leaveEventRepo,
+		holidayEventRepository: holidayEventRepo,
+		peopleRepository:       people,
+		notificationRepository: notificationRepo,
+		leaveTypes:             leaveTypes,
+	}
+}
+
+// LeaveReport is the leave taken in the month starting at Month.
+type LeaveReport struct {
+	Month       time.Time
+	WorkingDays int
+	// Types lists the leave types in report order, the configured ones followed by otherLeaveType.
+	Types []string
+	// Rows are sorted by team, then by name.
+	Rows []LeaveReportRow
+}
+
+type LeaveReportRow struct {
+	Name       string
+	Team       string
+	Days       float64
+	DaysByType map[string]float64
+}
+
+// WithExport also saves every report as CSV, named after its month, e.g. leave-2025-07.csv.
+func (l LeaveReportService) WithExport(store LeaveReportStore) LeaveReportService {
+	l.reportStore = store
+	return l
+}
+
+// Notify sends the report of the month before asOf; the job is scheduled on the first of the month.
+// The CSV export is saved before the report is sent, so a failing export fails the run before
+// anything is posted and a retry does not post the report twice.
+func (l LeaveReportService) Notify(asOf time.Time) error {
+	report, err := l.Report(PreviousMonth(asOf))
+	if err != nil {
+		return err
+	}
+	log.Printf("There are %d people who took leave in %s.", len(report.Rows), report.Month.Format("2006-01"))
+
+	if l.reportStore != nil {
+		var csv bytes.Buffer
+		if err := report.WriteCSV(&csv); err != nil {
+			return fmt.Errorf("Error while writing leave report: %v", err)
+		}
+		name := "leave-" + report.Month.Format("2006-01") + ".csv"
+		if err := l.reportStore.SaveLeaveReport(name, csv.Bytes()); err != nil {
+			log.Printf("Failed to save leave report: %v", err)
+			return fmt.Errorf("Error while saving leave report: %v", err)
+		}
+		log.Printf("Saved leave report %s", name)
+	}
+
+	err = l.notificationRepository.SendNotification(report.message())
+	if err != nil {
+		log.Printf("Failed to send notification: %v", err)
+		return fmt.Errorf("Error while sending notification: %v", err)
+	}
+	return nil
+}
+
+// PreviousMonth returns midnight of the first day of the month before asOf.
+func PreviousMonth(asOf time.Time) time.Time {
+	return time.Date(asOf.Year(), asOf.Month()-1, 1, 0, 0, 0, 0, asOf.Location())
+}
+
+// Report counts the leave taken in the month starting at monthStart. Only working days count,
+// weekends and holidays do not, and a timed leave of up to halfDayMaxDuration on a day is half a day.
+func (l LeaveReportService) Report(monthStart time.Time) (LeaveReport, error) {
+	monthEnd := monthStart.AddDate(0, 1, 0)
+	holidays, err := l.holidayEventRepository.ListEvents(monthStart, monthEnd)
+	if err != nil {
+		log.Printf("Error while getting holiday events: %v", err)
+		return LeaveReport{}, fmt.Errorf("Error while getting holiday events: %v", err)
+	}
+	leaveEvents, err := l.leaveEventRepository.ListEvents(monthStart, monthEnd)
+	if err != nil {
+		log.Printf("Error while getting leave events: %v", err)
+		return LeaveReport{}, fmt.Errorf("Error while getting leave events: %v", err)
+	}
+
+	report := LeaveReport{Month: monthStart}
+	for _, leaveType := range l.leaveTypes {
+		report.Types = append(report.Types, leaveType.Name)
+	}
+	report.Types = append(report.Types, otherLeaveType)
+
+	rows := map[string]*LeaveReportRow{}
+	for day := monthStart; day.Before(monthEnd); day = day.AddDate(0, 0, 1) {
+		dayEnd := day.AddDate(0, 0, 1)
+		if isWeekend(day) || len(eventSummariesBetween(holidays, day, dayEnd)) > 0 {
+			continue
+		}
+		report.WorkingDays++
+
+		// A person's leave adds up per type over the day, e.g. a sick morning and a sick afternoon make
+		// a whole day, and is capped at one day in total.
+		taken := map[string]map[string]float64{}
+		for _, event := range leaveEvents {
+			if !event.Start.Before(dayEnd) || !event.End.After(day) {
+				continue
+			}
+			name, team := l.person(event.Summary)
+			if _, ok := rows[name]; !ok {
+				rows[name] = &LeaveReportRow{Name: name, Team: team, DaysByType: map[string]float64{}}
+			}
+			if taken[name] == nil {
+				taken[name] = map[string]float64{}
+			}
+			leaveType := l.leaveType(event.Summary)
+			taken[name][leaveType] += leaveDaysOn(event, day)
+		}
+		for name, byType := range taken {
+			remaining := 1.0
+			for _, leaveType := range report.Types {
+				days := min(byType[leaveType], remaining)
+				remaining -= days
+				rows[name].DaysByType[leaveType] += days
+				rows[name].Days += days
+			}
+		}
+	}
+
+	for _, row := range rows {
+		report.Rows = append(report.Rows, *row)
+	}
+	sort.Slice(report.Rows, func(i, j int) bool {
+		if report.Rows[i].Team != report.Rows[j].Team {
+			return report.Rows[i].Team < report.Rows[j].Team
+		}
+		return report.Rows[i].Name < report.Rows[j].Name
+	})
+	return report, nil
+}
+
+// leaveDaysOn is how much of day a leave event takes: the part of a timed event on the day counts
+// as half a day when it is no longer than halfDayMaxDuration.
+func leaveDaysOn(event Event, day time.Time) float64 {
+	if event.AllDay {
+		return 1
+	}
+	return leaveDays(Event{Start: maxTime(event.Start, day), End: minTime(event.End, day.AddDate(0, 0, 1))})
+}
+
+func (l LeaveReportService) person(summary string) (string, string) {
+	if l.peopleRepository != nil {
+		if person, ok := l.peopleRepository.FindPerson(summary); ok {
+			return person.Name, person.Team
+		}
+	}
+	name, _, _ := strings.Cut(summary, " (")
+	name, _, _ = strings.Cut(name, " - ")
+	return strings.TrimSpace(name), ""
+}
+
+func (l LeaveReportService) leaveType(summary string) string {
+	summary = strings.ToLower(summary)
+	for _, leaveType := range l.leaveTypes {
+		for _, keyword := range leaveType.Keywords {
+			if strings.Contains(summary, strings.ToLower(keyword)) {
+				return leaveType.Name
+			}
+		}
+	}
+	return otherLeaveType
+}
+
+func (r LeaveReport) message() string {
+	month := monthEnToTh(r.Month.Format("January")) + " " + r.Month.Format("2006")
+	if len(r.Rows) == 0 {
+		return fmt.Sprintf("📊 เดือน %s ไม่มีใครลา 💪", month)
+	}
+
+	var lines []string
+	teamDays := map[string]float64{}
+	var teams []string
+	for _, row := range r.Rows {
+		var byType []string
+		for _, leaveType := range r.Types {
+			if days := row.DaysByType[leaveType]; days > 0 {
+				byType = append(byType, leaveType+" "+formatDays(days))
+			}
+		}
+		name := row.Name
+		if row.Team != "" {
+			name += " (" + row.Team + ")"
+			if _, ok := teamDays[row.Team]; !ok {
+				teams = append(teams, row.Team)
+			}
+			teamDays[row.Team] += row.Days
+		}
+		lines = append(lines, fmt.Sprintf("- %s: %s วัน (%s)", name, formatDays(row.Days), strings.Join(byType, ", ")))
+	}
+	message := fmt.Sprintf("📊 สรุปวันลาเดือน %s (วันทำงาน %d วัน)\n", month, r.WorkingDays) + strings.Join(lines, "\n")
+
+	if len(teams) > 0 {
+		var totals []string
+		for _, team := range teams {
+			totals = append(totals, fmt.Sprintf("- %s: %s วัน", team, formatDays(teamDays[team])))
+		}
+		message += "\n\n👥 รวมวันลาตามทีม\n" + strings.Join(totals, "\n")
+	}
+	return message
+}
+
+// WriteCSV writes one line per person with their days per leave type and in total.
+func (r LeaveReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	header := append([]string{"month", "name", "team"}, r.Types...)
+	header = append(header, "total", "working_days")
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, row := range r.Rows {
+		record := []string{r.Month.Format("2006-01"), row.Name, row.Team}
+		for _, leaveType := range r.Types {
+			record = append(record, formatDays(row.DaysByType[leaveType]))
+		}
+		record = append(record, formatDays(row.Days), fmt.Sprint(r.WorkingDays))
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
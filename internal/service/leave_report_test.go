@@ -0,0 +1,226 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newLeaveReportTestService(bangkok *time.Location, notification NotificationRepository) LeaveReportService {
+	july := time.Date(2025, 7, 1, 0, 0, 0, 0, bangkok)
+	leaveRepo := &MockEventRepository{listed: []Event{
+		// Friday to Tuesday: the weekend does not count.
+		allDay("Alice ลาพักร้อน", july.AddDate(0, 0, 3), 5),
+		// Bob's half-days on the same day add up to one day.
+		{Summary: "Bob ลาป่วย (morning)", Start: time.Date(2025, 7, 2, 9, 0, 0, 0, bangkok), End: time.Date(2025, 7, 2, 12, 0, 0, 0, bangkok)},
+		{Summary: "Bob ลากิจ (afternoon)", Start: time.Date(2025, 7, 2, 13, 0, 0, 0, bangkok), End: time.Date(2025, 7, 2, 18, 0, 0, 0, bangkok)},
+		{Summary: "Bob ลาป่วย (afternoon)", Start: time.Date(2025, 7, 15, 13, 0, 0, 0, bangkok), End: time.Date(2025, 7, 15, 18, 0, 0, 0, bangkok)},
+		// The holiday on Thursday does not count.
+		allDay("Eve", july.AddDate(0, 0, 9), 2),
+	}}
+	holidayRepo := &MockEventRepository{listed: []Event{allDay("วันอาสาฬหบูชา", july.AddDate(0, 0, 9), 1)}}
+	people := &MockPeopleRepository{people: []Person{
+		{Name: "Alice", Team: "backend"},
+		{Name: "Bob", Team: "backend"},
+		{Name: "Eve", Team: "frontend"},
+	}}
+	return NewLeaveReportService(leaveRepo, holidayRepo, people, notification, nil)
+}
+
+func TestLeaveReportService_Notify_ReportsPreviousMonth(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	mockNotification := &MockNotificationRepository{}
+	service := newLeaveReportTestService(bangkok, mockNotification)
+
+	// Act
+	err := service.Notify(time.Date(2025, 8, 1, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if mockNotification.numberOfCalls != 1 {
+		t.Fatalf("Expected notification to be called once, got %d", mockNotification.numberOfCalls)
+	}
+	expectedMessage := "📊 สรุปวันลาเดือน กรกฎาคม 2025 (วันทำงาน 22 วัน)\n" +
+		"- Alice (backend): 3 วัน (ลาพักร้อน 3)\n" +
+		"- Bob (backend): 1.5 วัน (ลาป่วย 1, ลากิจ 0.5)\n" +
+		"- Eve (frontend): 1 วัน (อื่นๆ 1)\n\n" +
+		"👥 รวมวันลาตามทีม\n" +
+		"- backend: 4.5 วัน\n" +
+		"- frontend: 1 วัน"
+	if mockNotification.sentMessage != expectedMessage {
+		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
+	}
+}
+
+func TestLeaveReport_WriteCSV(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	service := newLeaveReportTestService(bangkok, &MockNotificationRepository{})
+	report, err := service.Report(time.Date(2025, 7, 1, 0, 0, 0, 0, bangkok))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var buf bytes.Buffer
+
+	// Act
+	err = report.WriteCSV(&buf)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := "month,name,team,ลาป่วย,ลาพักร้อน,ลากิจ,อื่นๆ,total,working_days\n" +
+		"2025-07,Alice,backend,0,3,0,0,3,22\n" +
+		"2025-07,Bob,backend,1,0,0.5,0,1.5,22\n" +
+		"2025-07,Eve,frontend,0,0,0,1,1,22\n"
+	if buf.String() != expected {
+		t.Errorf("Expected CSV '%s', got '%s'", expected, buf.String())
+	}
+}
+
+type MockLeaveReportStore struct {
+	saved map[string]string
+	err   error
+}
+
+func (m *MockLeaveReportStore) SaveLeaveReport(name string, csv []byte) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.saved == nil {
+		m.saved = map[string]string{}
+	}
+	m.saved[name] = string(csv)
+	return nil
+}
+
+func TestLeaveReportService_Notify_SavesCSVExport(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	mockNotification := &MockNotificationRepository{}
+	store := &MockLeaveReportStore{}
+	service := newLeaveReportTestService(bangkok, mockNotification).WithExport(store)
+
+	// Act
+	err := service.Notify(time.Date(2025, 8, 1, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if mockNotification.numberOfCalls != 1 {
+		t.Errorf("Expected notification to be called once, got %d", mockNotification.numberOfCalls)
+	}
+	if csv := store.saved["leave-2025-07.csv"]; !strings.HasPrefix(csv, "month,name,team,") || !strings.Contains(csv, "2025-07,Bob,backend,1,0,0.5,0,1.5,22\n") {
+		t.Errorf("Unexpected exports %v", store.saved)
+	}
+}
+
+func TestLeaveReportService_Notify_ExportErrorSendsNothing(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	mockNotification := &MockNotificationRepository{}
+	service := newLeaveReportTestService(bangkok, mockNotification).WithExport(&MockLeaveReportStore{err: errors.New("access denied")})
+
+	// Act
+	err := service.Notify(time.Date(2025, 8, 1, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+	if mockNotification.numberOfCalls != 0 {
+		t.Errorf("Expected notification not to be called, got %d", mockNotification.numberOfCalls)
+	}
+}
+
+func TestLeaveReportService_Notify_NoLeave(t *testing.T) {
+	// Arrange
+	mockNotification := &MockNotificationRepository{}
+	service := NewLeaveReportService(&MockEventRepository{}, &MockEventRepository{}, nil, mockNotification, nil)
+
+	// Act
+	err := service.Notify(time.Date(2025, 8, 1, 8, 0, 0, 0, time.UTC))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedMessage := "📊 เดือน กรกฎาคม 2025 ไม่มีใครลา 💪"
+	if mockNotification.sentMessage != expectedMessage {
+		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
+	}
+}
+
+func TestLeaveReportService_Report_NamesWithoutPeopleDirectory(t *testing.T) {
+	// Arrange
+	july := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	leaveRepo := &MockEventRepository{listed: []Event{allDay("Carol (sick)", july, 1), allDay("Carol - vacation", july.AddDate(0, 0, 1), 1)}}
+	service := NewLeaveReportService(leaveRepo, &MockEventRepository{}, nil, &MockNotificationRepository{}, nil)
+
+	// Act
+	report, err := service.Report(july)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(report.Rows) != 1 {
+		t.Fatalf("Expected one row, got %v", report.Rows)
+	}
+	row := report.Rows[0]
+	if row.Name != "Carol" || row.Days != 2 || row.DaysByType["ลาป่วย"] != 1 || row.DaysByType["ลาพักร้อน"] != 1 {
+		t.Errorf("Unexpected row %+v", row)
+	}
+}
+
+func TestLeaveReportService_Report_SameTypeHalfDaysMakeADay(t *testing.T) {
+	// Arrange
+	july := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	leaveRepo := &MockEventRepository{listed: []Event{
+		{Summary: "Bob ลาป่วย (morning)", Start: time.Date(2025, 7, 2, 9, 0, 0, 0, time.UTC), End: time.Date(2025, 7, 2, 12, 0, 0, 0, time.UTC)},
+		{Summary: "Bob ลาป่วย (afternoon)", Start: time.Date(2025, 7, 2, 13, 0, 0, 0, time.UTC), End: time.Date(2025, 7, 2, 18, 0, 0, 0, time.UTC)},
+		// A duplicated all-day event still counts as one day.
+		allDay("Bob ลาป่วย", july.AddDate(0, 0, 2), 1),
+		allDay("Bob ลาป่วย", july.AddDate(0, 0, 2), 1),
+	}}
+	service := NewLeaveReportService(leaveRepo, &MockEventRepository{}, nil, &MockNotificationRepository{}, nil)
+
+	// Act
+	report, err := service.Report(july)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(report.Rows) != 1 {
+		t.Fatalf("Expected one row, got %v", report.Rows)
+	}
+	row := report.Rows[0]
+	if row.Days != 2 || row.DaysByType["ลาป่วย"] != 2 {
+		t.Errorf("Expected two days of ลาป่วย, got %+v", row)
+	}
+}
+
+func TestLeaveReportService_Notify_GetEventsError(t *testing.T) {
+	// Arrange
+	mockNotification := &MockNotificationRepository{}
+	leaveRepo := &MockEventRepository{err: errors.New("calendar unavailable")}
+	service := NewLeaveReportService(leaveRepo, &MockEventRepository{}, nil, mockNotification, nil)
+
+	// Act
+	err := service.Notify(time.Date(2025, 8, 1, 8, 0, 0, 0, time.UTC))
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+	if mockNotification.numberOfCalls != 0 {
+		t.Errorf("Expected notification not to be called, got %d", mockNotification.numberOfCalls)
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// maxDaysToNextWorkingDay bounds the search for the next working day, longer than any holiday break.
+const maxDaysToNextWorkingDay = 30
+
+// NextWorkingDayTemplate holds the section headings of the evening message. In a heading, {when}
+// becomes "พรุ่งนี้" or "วันทำงานถัดไป" and {date} the date and weekday of the next working day.
+type NextWorkingDayTemplate struct {
+	LeaveHeading  string
+	OnCallHeading string
+}
+
+// DefaultNextWorkingDayTemplate is used for the headings that are not configured.
+var DefaultNextWorkingDayTemplate = NextWorkingDayTemplate{
+	LeaveHeading:  "🌙 {when}ใครลา : ({date})",
+	OnCallHeading: "📞 {when}ใคร On-Call : ({date})",
+}
+
+// WithNextWorkingDayTemplate sets the headings of the evening message; empty headings keep the default.
+func (e EventNotifyService) WithNextWorkingDayTemplate(template NextWorkingDayTemplate) EventNotifyService {
+	e.nextWorkingDayTemplate = template
+	return e
+}
+
+func (t NextWorkingDayTemplate) heading(heading, fallback, when, date string) string {
+	if heading == "" {
+		heading = fallback
+	}
+	return strings.NewReplacer("{when}", when, "{date}", date).Replace(heading)
+}
+
+// NotifyNextWorkingDay is the evening counterpart of Notify: it lists who is on leave and on call
+// on the next working day, skipping weekends and holidays, so people know before they go home.
+func (e EventNotifyService) NotifyNextWorkingDay(asOf time.Time) error {
+	day, err := e.nextWorkingDay(asOf)
+	if err != nil {
+		return err
+	}
+
+	leaveEvents, err := e.leaveEventRepository.GetEvents(day)
+	if err != nil {
+		log.Printf("Error while getting leave events: %v", err)
+		return fmt.Errorf("Error while getting leave events: %v", err)
+	}
+	onCallEvents, err := e.onCallEventRepository.GetEvents(day)
+	if err != nil {
+		log.Printf("Error while getting on-call events: %v", err)
+		return fmt.Errorf("Error while getting on-call events: %v", err)
+	}
+	if len(leaveEvents) == 0 && len(onCallEvents) == 0 {
+		log.Println("There are no events on the next working day " + day.Format(time.DateOnly))
+		return nil
+	}
+
+	when := "พรุ่งนี้"
+	if !sameDate(day, asOf.AddDate(0, 0, 1)) {
+		when = "วันทำงานถัดไป"
+	}
+	date := day.Format(time.DateOnly) + " " + weekdayTh(day.Weekday())
+	template := e.nextWorkingDayTemplate
+	var sections []string
+	if len(leaveEvents) > 0 {
+		log.Printf("There are %d on leave on %s.", len(leaveEvents), day.Format(time.DateOnly))
+		heading := template.heading(template.LeaveHeading, DefaultNextWorkingDayTemplate.LeaveHeading, when, date)
+		sections = append(sections, heading+"\n- "+strings.Join(leaveEvents, "\n- "))
+	}
+	if len(onCallEvents) > 0 {
+		heading := template.heading(template.OnCallHeading, DefaultNextWorkingDayTemplate.OnCallHeading, when, date)
+		sections = append(sections, heading+"\n- "+strings.Join(onCallEvents, "\n- "))
+	}
+
+	err = e.notificationRepository.SendNotification(strings.Join(sections, "\n\n"))
+	if err != nil {
+		log.Printf("Failed to send notification: %v", err)
+		return fmt.Errorf("Error while sending notification: %v", err)
+	}
+	return nil
+}
+
+// nextWorkingDay returns midnight of the first day after asOf that is neither a weekend nor on the
+// holiday calendar.
+func (e EventNotifyService) nextWorkingDay(asOf time.Time) (time.Time, error) {
+	from := time.Date(asOf.Year(), asOf.Month(), asOf.Day()+1, 0, 0, 0, 0, asOf.Location())
+	until := from.AddDate(0, 0, maxDaysToNextWorkingDay)
+	holidays, err := e.holidayEventRepository.ListEvents(from, until)
+	if err != nil {
+		log.Printf("Error while getting holiday events: %v", err)
+		return time.Time{}, fmt.Errorf("Error while getting holiday events: %v", err)
+	}
+	for day := from; day.Before(until); day = day.AddDate(0, 0, 1) {
+		if !isWeekend(day) && len(eventSummariesBetween(holidays, day, day.AddDate(0, 0, 1))) == 0 {
+			return day, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("no working day within %d days after %s", maxDaysToNextWorkingDay, asOf.Format(time.DateOnly))
+}
+
+func isWeekend(day time.Time) bool {
+	return day.Weekday() == time.Saturday || day.Weekday() == time.Sunday
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEventNotifyService_NotifyNextWorkingDay_Tomorrow(t *testing.T) {
+	// Arrange
+	mockNotification := &MockNotificationRepository{}
+	leaveRepo := &MockEventRepository{events: []string{"Alice", "Bob"}}
+	onCallRepo := &MockEventRepository{events: []string{"Carol"}}
+	service := NewEventNotifyService(leaveRepo, &MockEventRepository{}, onCallRepo, mockNotification)
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	// Act
+	err := service.NotifyNextWorkingDay(time.Date(2025, 8, 12, 17, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedMessage := "🌙 พรุ่งนี้ใครลา : (2025-08-13 พุธ)\n- Alice\n- Bob\n\n📞 พรุ่งนี้ใคร On-Call : (2025-08-13 พุธ)\n- Carol"
+	if mockNotification.sentMessage != expectedMessage {
+		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
+	}
+}
+
+func TestEventNotifyService_NotifyNextWorkingDay_ConfiguredTemplate(t *testing.T) {
+	// Arrange
+	mockNotification := &MockNotificationRepository{}
+	leaveRepo := &MockEventRepository{events: []string{"Alice"}}
+	onCallRepo := &MockEventRepository{events: []string{"Carol"}}
+	service := NewEventNotifyService(leaveRepo, &MockEventRepository{}, onCallRepo, mockNotification).
+		WithNextWorkingDayTemplate(NextWorkingDayTemplate{LeaveHeading: "🏠 ลา{when} {date}"})
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+
+	// Act
+	err := service.NotifyNextWorkingDay(time.Date(2025, 8, 12, 17, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedMessage := "🏠 ลาพรุ่งนี้ 2025-08-13 พุธ\n- Alice\n\n📞 พรุ่งนี้ใคร On-Call : (2025-08-13 พุธ)\n- Carol"
+	if mockNotification.sentMessage != expectedMessage {
+		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
+	}
+}
+
+func TestEventNotifyService_NotifyNextWorkingDay_SkipsWeekendAndHoliday(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	mockNotification := &MockNotificationRepository{}
+	leaveRepo := &MockEventRepository{events: []string{"Alice"}}
+	holidayRepo := &MockEventRepository{listed: []Event{allDay("วันหยุดชดเชย", time.Date(2025, 8, 18, 0, 0, 0, 0, bangkok), 1)}}
+	service := NewEventNotifyService(leaveRepo, holidayRepo, &MockEventRepository{}, mockNotification)
+
+	// Act
+	err := service.NotifyNextWorkingDay(time.Date(2025, 8, 15, 17, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedMessage := "🌙 วันทำงานถัดไปใครลา : (2025-08-19 อังคาร)\n- Alice"
+	if mockNotification.sentMessage != expectedMessage {
+		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
+	}
+}
+
+func TestEventNotifyService_NotifyNextWorkingDay_NoEvents(t *testing.T) {
+	// Arrange
+	mockNotification := &MockNotificationRepository{}
+	service := NewEventNotifyService(&MockEventRepository{}, &MockEventRepository{}, &MockEventRepository{}, mockNotification)
+
+	// Act
+	err := service.NotifyNextWorkingDay(time.Date(2025, 8, 12, 17, 0, 0, 0, time.UTC))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if mockNotification.numberOfCalls != 0 {
+		t.Errorf("Expected no notification, got %d", mockNotification.numberOfCalls)
+	}
+}
+
+func TestEventNotifyService_NotifyNextWorkingDay_HolidayError(t *testing.T) {
+	// Arrange
+	mockNotification := &MockNotificationRepository{}
+	holidayRepo := &MockEventRepository{err: errors.New("calendar unavailable")}
+	service := NewEventNotifyService(&MockEventRepository{events: []string{"Alice"}}, holidayRepo, &MockEventRepository{}, mockNotification)
+
+	// Act
+	err := service.NotifyNextWorkingDay(time.Date(2025, 8, 12, 17, 0, 0, 0, time.UTC))
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+	if mockNotification.numberOfCalls != 0 {
+		t.Errorf("Expected no notification, got %d", mockNotification.numberOfCalls)
+	}
+}
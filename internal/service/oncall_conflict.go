@@ -0,0 +1,145 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// OnCallConflictService warns when somebody is on call while on leave, checking today and the
+// following lookahead days so the rotation can be swapped before the day comes.
+type OnCallConflictService struct {
+	leaveEventRepository  EventRepository
+	onCallEventRepository EventRepository
+	// peopleRepository matches leave and on-call events written differently, e.g. "Alice (afternoon)"
+	// and "Alice Smith"; without it the summaries must be equal.
+	peopleRepository       PeopleRepository
+	notificationRepository NotificationRepository
+	lookaheadDays          int
+}
+
+func NewOnCallConflictService(leaveEventRepo, onCallEventRepo EventRepository, people PeopleRepository,
+	notificationRepo NotificationRepository, lookaheadDays int) OnCallConflictService {
+	return OnCallConflictService{
+		leaveEventRepository:   leaveEventRepo,
+		onCallEventRepository:  onCallEventRepo,
+		peopleRepository:       people,
+		notificationRepository: notificationRepo,
+		lookaheadDays:          lookaheadDays,
+	}
+}
+
+// onCallConflict is a person who is on call while on leave on the listed days.
+type onCallConflict struct {
+	name string
+	days []time.Time
+}
+
+func (c OnCallConflictService) Notify(asOf time.Time) error {
+	from := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, asOf.Location())
+	until := from.AddDate(0, 0, c.lookaheadDays+1)
+	leaveEvents, err := c.leaveEventRepository.ListEvents(from, until)
+	if err != nil {
+		log.Printf("Error while getting leave events: %v", err)
+		return fmt.Errorf("Error while getting leave events: %v", err)
+	}
+	onCallEvents, err := c.onCallEventRepository.ListEvents(from, until)
+	if err != nil {
+		log.Printf("Error while getting on-call events: %v", err)
+		return fmt.Errorf("Error while getting on-call events: %v", err)
+	}
+
+	conflicts := c.findConflicts(from, until, leaveEvents, onCallEvents)
+	if len(conflicts) == 0 {
+		log.Printf("No on-call conflicts with leave from %s for %d days", from.Format(time.DateOnly), c.lookaheadDays+1)
+		return nil
+	}
+	log.Printf("There are %d people on call while on leave.", len(conflicts))
+
+	var lines []string
+	for _, conflict := range conflicts {
+		var ranges []string
+		first := conflict.days[0]
+		for i := 1; i < len(conflict.days); i++ {
+			if !sameDate(conflict.days[i-1].AddDate(0, 0, 1), conflict.days[i]) {
+				ranges = append(ranges, formatDateRange(first, conflict.days[i-1]))
+				first = conflict.days[i]
+			}
+		}
+		ranges = append(ranges, formatDateRange(first, conflict.days[len(conflict.days)-1]))
+		lines = append(lines, "- "+conflict.name+": "+strings.Join(ranges, ", "))
+	}
+	message := "🚨 เวร On-Call ชนกับวันลา\n" + strings.Join(lines, "\n")
+
+	err = c.notificationRepository.SendNotification(message)
+	if err != nil {
+		log.Printf("Failed to send notification: %v", err)
+		return fmt.Errorf("Error while sending notification: %v", err)
+	}
+	return nil
+}
+
+// findConflicts returns, in order of the first conflicting day, everybody whose on-call shift
+// overlaps their own leave within the daily window of a day in [from, until).
+func (c OnCallConflictService) findConflicts(from, until time.Time, leaveEvents, onCallEvents []Event) []onCallConflict {
+	var conflicts []onCallConflict
+	index := map[string]int{}
+	for day := from; day.Before(until); day = day.AddDate(0, 0, 1) {
+		start, end := dailyWindow(day)
+		for _, onCall := range onCallEvents {
+			key, name := c.person(onCall.Summary)
+			if !c.onLeaveDuring(key, leaveEvents, maxTime(start, onCall.Start), minTime(end, onCall.End)) {
+				continue
+			}
+			i, ok := index[key]
+			if !ok {
+				i = len(conflicts)
+				index[key] = i
+				conflicts = append(conflicts, onCallConflict{name: name})
+			}
+			if days := conflicts[i].days; len(days) == 0 || !sameDate(days[len(days)-1], day) {
+				conflicts[i].days = append(conflicts[i].days, day)
+			}
+		}
+	}
+	return conflicts
+}
+
+func (c OnCallConflictService) onLeaveDuring(key string, leaveEvents []Event, start, end time.Time) bool {
+	if !start.Before(end) {
+		return false
+	}
+	for _, leave := range leaveEvents {
+		if leave.Start.Before(end) && leave.End.After(start) {
+			if leaveKey, _ := c.person(leave.Summary); leaveKey == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// person returns the key an event summary is matched on and the name to report it under.
+func (c OnCallConflictService) person(summary string) (string, string) {
+	if c.peopleRepository != nil {
+		if person, ok := c.peopleRepository.FindPerson(summary); ok {
+			return person.Name, person.Name
+		}
+	}
+	return strings.ToLower(strings.TrimSpace(summary)), strings.TrimSpace(summary)
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
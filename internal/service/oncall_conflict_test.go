@@ -0,0 +1,109 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOnCallConflictService_Notify_ReportsPeopleOnCallWhileOnLeave(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	monday := time.Date(2025, 8, 11, 0, 0, 0, 0, bangkok)
+	leaveRepo := &MockEventRepository{listed: []Event{
+		allDay("Alice", monday.AddDate(0, 0, 1), 2),
+		allDay("Alice", monday.AddDate(0, 0, 5), 1),
+		{Summary: "Bob (afternoon)", Start: time.Date(2025, 8, 13, 13, 0, 0, 0, bangkok), End: time.Date(2025, 8, 13, 18, 0, 0, 0, bangkok)},
+		allDay("Carol", monday, 1),
+	}}
+	onCallRepo := &MockEventRepository{listed: []Event{
+		{Summary: "Alice", Start: time.Date(2025, 8, 11, 9, 0, 0, 0, bangkok), End: time.Date(2025, 8, 18, 9, 0, 0, 0, bangkok)},
+		{Summary: "Bob", Start: time.Date(2025, 8, 13, 9, 0, 0, 0, bangkok), End: time.Date(2025, 8, 13, 18, 0, 0, 0, bangkok)},
+	}}
+	people := &MockPeopleRepository{people: []Person{{Name: "Alice"}, {Name: "Bob"}, {Name: "Carol"}}}
+	mockNotification := &MockNotificationRepository{}
+	service := NewOnCallConflictService(leaveRepo, onCallRepo, people, mockNotification, 7)
+
+	// Act
+	err := service.Notify(time.Date(2025, 8, 11, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if mockNotification.numberOfCalls != 1 {
+		t.Fatalf("Expected notification to be called once, got %d", mockNotification.numberOfCalls)
+	}
+	// Carol is on leave but not on call; Bob's half-day overlaps his daytime shift.
+	expectedMessage := "🚨 เวร On-Call ชนกับวันลา\n" +
+		"- Alice: 2025-08-12 - 2025-08-13, 2025-08-16\n" +
+		"- Bob: 2025-08-13"
+	if mockNotification.sentMessage != expectedMessage {
+		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
+	}
+}
+
+func TestOnCallConflictService_Notify_NoConflict(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	monday := time.Date(2025, 8, 11, 0, 0, 0, 0, bangkok)
+	// Alice's shift ends on the morning her leave starts, before the daily roster window.
+	leaveRepo := &MockEventRepository{listed: []Event{allDay("Alice", monday, 1)}}
+	onCallRepo := &MockEventRepository{listed: []Event{
+		{Summary: "Alice", Start: time.Date(2025, 8, 4, 9, 0, 0, 0, bangkok), End: time.Date(2025, 8, 11, 9, 0, 0, 0, bangkok)},
+		{Summary: "Bob", Start: time.Date(2025, 8, 11, 9, 0, 0, 0, bangkok), End: time.Date(2025, 8, 18, 9, 0, 0, 0, bangkok)},
+	}}
+	mockNotification := &MockNotificationRepository{}
+	service := NewOnCallConflictService(leaveRepo, onCallRepo, nil, mockNotification, 0)
+
+	// Act
+	err := service.Notify(time.Date(2025, 8, 11, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if mockNotification.numberOfCalls != 0 {
+		t.Errorf("Expected notification not to be called, got %d", mockNotification.numberOfCalls)
+	}
+}
+
+func TestOnCallConflictService_Notify_MatchesSummariesWithoutPeopleDirectory(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	monday := time.Date(2025, 8, 11, 0, 0, 0, 0, bangkok)
+	leaveRepo := &MockEventRepository{listed: []Event{allDay("alice", monday, 1)}}
+	onCallRepo := &MockEventRepository{listed: []Event{allDay("Alice", monday, 1)}}
+	mockNotification := &MockNotificationRepository{}
+	service := NewOnCallConflictService(leaveRepo, onCallRepo, nil, mockNotification, 0)
+
+	// Act
+	err := service.Notify(monday.Add(8 * time.Hour))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedMessage := "🚨 เวร On-Call ชนกับวันลา\n- Alice: 2025-08-11"
+	if mockNotification.sentMessage != expectedMessage {
+		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
+	}
+}
+
+func TestOnCallConflictService_Notify_LeaveError(t *testing.T) {
+	// Arrange
+	leaveRepo := &MockEventRepository{err: errors.New("calendar unavailable")}
+	mockNotification := &MockNotificationRepository{}
+	service := NewOnCallConflictService(leaveRepo, &MockEventRepository{}, nil, mockNotification, 7)
+
+	// Act
+	err := service.Notify(time.Date(2025, 8, 11, 8, 0, 0, 0, time.UTC))
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+	if mockNotification.numberOfCalls != 0 {
+		t.Errorf("Expected notification not to be called, got %d", mockNotification.numberOfCalls)
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// noOnCallAssigned marks a day or a time range nobody is on call for.
+const noOnCallAssigned = "⚠️ ยังไม่มีคน On-Call"
+
+// OnCallCoverageService alerts the team lead to time ranges in the coming days that nobody is on
+// call for, whole weekends as well as the hours between two timed shifts that do not meet.
+type OnCallCoverageService struct {
+	onCallEventRepository  EventRepository
+	notificationRepository NotificationRepository
+	lookaheadDays          int
+}
+
+func NewOnCallCoverageService(onCallEventRepo EventRepository, notificationRepo NotificationRepository,
+	lookaheadDays int) OnCallCoverageService {
+	return OnCallCoverageService{
+		onCallEventRepository:  onCallEventRepo,
+		notificationRepository: notificationRepo,
+		lookaheadDays:          lookaheadDays,
+	}
+}
+
+// coverageGap is a time range [start, end) without an on-call assignee.
+type coverageGap struct {
+	start time.Time
+	end   time.Time
+}
+
+// Notify scans from the start of asOf's daily window until the end of the lookahead's last day,
+// so a retry later in the day sends the same message.
+func (c OnCallCoverageService) Notify(asOf time.Time) error {
+	from, _ := dailyWindow(asOf)
+	until := time.Date(asOf.Year(), asOf.Month(), asOf.Day()+c.lookaheadDays+1, 0, 0, 0, 0, asOf.Location())
+	onCallEvents, err := c.onCallEventRepository.ListEvents(from, until)
+	if err != nil {
+		log.Printf("Error while getting on-call events: %v", err)
+		return fmt.Errorf("Error while getting on-call events: %v", err)
+	}
+
+	gaps := coverageGaps(onCallEvents, from, until)
+	if len(gaps) == 0 {
+		log.Printf("On-call is covered until %s", until.Format(time.DateOnly))
+		return nil
+	}
+	log.Printf("There are %d on-call coverage gaps until %s.", len(gaps), until.Format(time.DateOnly))
+
+	var lines []string
+	for _, gap := range gaps {
+		lines = append(lines, fmt.Sprintf("- %s - %s (%s)", formatGapTime(gap.start), formatGapTime(gap.end), formatGapDuration(gap.end.Sub(gap.start))))
+	}
+	message := fmt.Sprintf("%s : (%s)\n", noOnCallAssigned, formatDateRange(asOf, until.AddDate(0, 0, -1))) + strings.Join(lines, "\n")
+
+	err = c.notificationRepository.SendNotification(message)
+	if err != nil {
+		log.Printf("Failed to send notification: %v", err)
+		return fmt.Errorf("Error while sending notification: %v", err)
+	}
+	return nil
+}
+
+// coverageGaps returns the parts of [from, until) not covered by any event, in order.
+func coverageGaps(events []Event, from, until time.Time) []coverageGap {
+	sorted := append([]Event(nil), events...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	var gaps []coverageGap
+	covered := from
+	for _, event := range sorted {
+		if !covered.Before(until) {
+			break
+		}
+		if event.Start.After(covered) {
+			gaps = append(gaps, coverageGap{start: covered, end: minTime(event.Start, until)})
+		}
+		covered = maxTime(covered, event.End)
+	}
+	if covered.Before(until) {
+		gaps = append(gaps, coverageGap{start: covered, end: until})
+	}
+	return gaps
+}
+
+func formatGapTime(t time.Time) string {
+	return t.Format(time.DateOnly) + " " + weekdayTh(t.Weekday()) + " " + t.Format("15:04")
+}
+
+// formatGapDuration shows a gap's length in days, hours and minutes, leaving out zero parts.
+func formatGapDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	days, hours, minutes := int(d/(24*time.Hour)), int(d%(24*time.Hour)/time.Hour), int(d%time.Hour/time.Minute)
+	var parts []string
+	if days > 0 {
+		parts = append(parts, fmt.Sprintf("%d วัน", days))
+	}
+	if hours > 0 {
+		parts = append(parts, fmt.Sprintf("%d ชม.", hours))
+	}
+	if minutes > 0 || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%d นาที", minutes))
+	}
+	return strings.Join(parts, " ")
+}
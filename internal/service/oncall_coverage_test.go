@@ -0,0 +1,118 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOnCallCoverageService_Notify_ReportsGaps(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	onCallRepo := &MockEventRepository{listed: []Event{
+		{Summary: "Alice", Start: time.Date(2025, 8, 11, 9, 30, 0, 0, bangkok), End: time.Date(2025, 8, 11, 18, 0, 0, 0, bangkok)},
+		{Summary: "Bob", Start: time.Date(2025, 8, 11, 18, 30, 0, 0, bangkok), End: time.Date(2025, 8, 16, 0, 0, 0, 0, bangkok)},
+		{Summary: "Carol", Start: time.Date(2025, 8, 15, 9, 0, 0, 0, bangkok), End: time.Date(2025, 8, 15, 18, 0, 0, 0, bangkok)},
+	}}
+	mockNotification := &MockNotificationRepository{}
+	service := NewOnCallCoverageService(onCallRepo, mockNotification, 6)
+
+	// Act: a retry later in the day scans from 09:00 all the same.
+	err := service.Notify(time.Date(2025, 8, 11, 10, 17, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if mockNotification.numberOfCalls != 1 {
+		t.Fatalf("Expected notification to be called once, got %d", mockNotification.numberOfCalls)
+	}
+	expectedMessage := "⚠️ ยังไม่มีคน On-Call : (2025-08-11 - 2025-08-17)\n" +
+		"- 2025-08-11 จันทร์ 09:00 - 2025-08-11 จันทร์ 09:30 (30 นาที)\n" +
+		"- 2025-08-11 จันทร์ 18:00 - 2025-08-11 จันทร์ 18:30 (30 นาที)\n" +
+		"- 2025-08-16 เสาร์ 00:00 - 2025-08-18 จันทร์ 00:00 (2 วัน)"
+	if mockNotification.sentMessage != expectedMessage {
+		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
+	}
+}
+
+func TestOnCallCoverageService_Notify_FullyCovered(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	onCallRepo := &MockEventRepository{listed: []Event{
+		{Summary: "Alice", Start: time.Date(2025, 8, 4, 9, 0, 0, 0, bangkok), End: time.Date(2025, 8, 11, 9, 0, 0, 0, bangkok)},
+		{Summary: "Bob", Start: time.Date(2025, 8, 11, 9, 0, 0, 0, bangkok), End: time.Date(2025, 8, 18, 9, 0, 0, 0, bangkok)},
+	}}
+	mockNotification := &MockNotificationRepository{}
+	service := NewOnCallCoverageService(onCallRepo, mockNotification, 6)
+
+	// Act
+	err := service.Notify(time.Date(2025, 8, 11, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if mockNotification.numberOfCalls != 0 {
+		t.Errorf("Expected notification not to be called, got %d", mockNotification.numberOfCalls)
+	}
+}
+
+func TestOnCallCoverageService_Notify_GetEventsError(t *testing.T) {
+	// Arrange
+	onCallRepo := &MockEventRepository{err: errors.New("calendar unavailable")}
+	mockNotification := &MockNotificationRepository{}
+	service := NewOnCallCoverageService(onCallRepo, mockNotification, 6)
+
+	// Act
+	err := service.Notify(time.Date(2025, 8, 11, 8, 0, 0, 0, time.UTC))
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+	if mockNotification.numberOfCalls != 0 {
+		t.Errorf("Expected notification not to be called, got %d", mockNotification.numberOfCalls)
+	}
+}
+
+func TestEventNotifyService_Notify_WarnsWhenNobodyIsOnCall(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	mockNotification := &MockNotificationRepository{}
+	service := NewEventNotifyService(&MockEventRepository{}, &MockEventRepository{}, &MockEventRepository{}, mockNotification).
+		WithOnCallRequired()
+
+	// Act
+	err := service.Notify(time.Date(2025, 8, 16, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedMessage := "📞 วันนี้ใคร On-Call : (2025-08-16)\n- ⚠️ ยังไม่มีคน On-Call"
+	if mockNotification.sentMessage != expectedMessage {
+		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
+	}
+}
+
+func TestEventNotifyService_Notify_WarnsWhenNobodyIsOnCallOnWorkingDay(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	mockNotification := &MockNotificationRepository{}
+	leaveRepo := &MockEventRepository{events: []string{"Alice"}}
+	service := NewEventNotifyService(leaveRepo, &MockEventRepository{}, &MockEventRepository{}, mockNotification).
+		WithOnCallRequired()
+
+	// Act
+	err := service.Notify(time.Date(2025, 8, 13, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedMessage := "📅 วันนี้ใครลา : (2025-08-13)\n- Alice\n\n📞 วันนี้ใคร On-Call : (2025-08-13)\n- ⚠️ ยังไม่มีคน On-Call"
+	if mockNotification.sentMessage != expectedMessage {
+		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
+	}
+}
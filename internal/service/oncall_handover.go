@@ -0,0 +1,199 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// handoverCoverageDays caps how far ahead the weekend and holiday coverage of a handover is listed.
+const handoverCoverageDays = 31
+
+// OnCallHandoverService announces the on-call handover on the day the assignee changes. The
+// change is found by comparing who is on call just before and at each shift start of the day.
+type OnCallHandoverService struct {
+	onCallEventRepository  EventRepository
+	holidayEventRepository EventRepository
+	notificationRepository NotificationRepository
+}
+
+func NewOnCallHandoverService(onCallEventRepo, holidayEventRepo EventRepository,
+	notificationRepo NotificationRepository) OnCallHandoverService {
+	return OnCallHandoverService{
+		onCallEventRepository:  onCallEventRepo,
+		holidayEventRepository: holidayEventRepo,
+		notificationRepository: notificationRepo,
+	}
+}
+
+// onCallHandover is a change of on-call assignees at a shift boundary.
+type onCallHandover struct {
+	at       time.Time
+	outgoing []Event
+	incoming []Event
+}
+
+func (h OnCallHandoverService) Notify(asOf time.Time) error {
+	dayStart := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, asOf.Location())
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	onCallEvents, err := h.onCallEventRepository.ListEvents(dayStart.AddDate(0, 0, -1), dayEnd)
+	if err != nil {
+		log.Printf("Error while getting on-call events: %v", err)
+		return fmt.Errorf("Error while getting on-call events: %v", err)
+	}
+
+	handover, ok := findOnCallHandover(onCallEvents, dayStart, dayEnd)
+	if !ok {
+		log.Println("No on-call handover on " + asOf.Format(time.DateOnly))
+		return nil
+	}
+	log.Printf("On-call handover at %s", handover.at.Format(time.RFC3339))
+
+	message := fmt.Sprintf("🔄 ส่งต่อเวร On-Call : (%s)\n", asOf.Format(time.DateOnly))
+	var lines []string
+	for _, event := range handover.outgoing {
+		lines = append(lines, "- ส่งเวร: "+event.Summary+" ("+formatShift(event)+")")
+	}
+	for _, event := range handover.incoming {
+		lines = append(lines, "- รับเวร: "+event.Summary+" ("+formatShift(event)+")")
+	}
+	message += strings.Join(lines, "\n")
+
+	coverage, err := h.coverage(dayEnd, handover.incoming)
+	if err != nil {
+		return err
+	}
+	if len(coverage) > 0 {
+		message += "\n\n📞 ใคร On-Call วันหยุดถัดไป\n" + strings.Join(coverage, "\n")
+	}
+
+	err = h.notificationRepository.SendNotification(message)
+	if err != nil {
+		log.Printf("Failed to send notification: %v", err)
+		return fmt.Errorf("Error while sending notification: %v", err)
+	}
+	return nil
+}
+
+// findOnCallHandover returns the first shift start in [dayStart, dayEnd) at which somebody who
+// was not on call just before takes over.
+func findOnCallHandover(events []Event, dayStart, dayEnd time.Time) (onCallHandover, bool) {
+	var boundaries []time.Time
+	for _, event := range events {
+		if !event.Start.Before(dayStart) && event.Start.Before(dayEnd) {
+			boundaries = append(boundaries, event.Start)
+		}
+	}
+	sort.Slice(boundaries, func(i, j int) bool { return boundaries[i].Before(boundaries[j]) })
+
+	for _, boundary := range boundaries {
+		before := eventsAt(events, boundary.Add(-time.Nanosecond))
+		after := eventsAt(events, boundary)
+		incoming := eventsWithoutSummaries(after, before)
+		if len(incoming) > 0 {
+			return onCallHandover{at: boundary, outgoing: eventsWithoutSummaries(before, after), incoming: incoming}, true
+		}
+	}
+	return onCallHandover{}, false
+}
+
+func eventsAt(events []Event, at time.Time) []Event {
+	var active []Event
+	for _, event := range events {
+		if !event.Start.After(at) && event.End.After(at) {
+			active = append(active, event)
+		}
+	}
+	return active
+}
+
+// eventsWithoutSummaries returns the events whose summary does not appear in others.
+func eventsWithoutSummaries(events, others []Event) []Event {
+	summaries := map[string]bool{}
+	for _, other := range others {
+		summaries[other.Summary] = true
+	}
+	var result []Event
+	for _, event := range events {
+		if !summaries[event.Summary] {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// coverage lists who is on call on every weekend day and holiday from `from` until the incoming
+// shift ends, and at least through the next weekend.
+func (h OnCallHandoverService) coverage(from time.Time, incoming []Event) ([]string, error) {
+	until := from
+	for until.Weekday() != time.Monday || !until.After(from.AddDate(0, 0, 1)) {
+		until = until.AddDate(0, 0, 1)
+	}
+	for _, event := range incoming {
+		if event.End.After(until) {
+			until = event.End
+		}
+	}
+	if limit := from.AddDate(0, 0, handoverCoverageDays); until.After(limit) {
+		until = limit
+	}
+
+	holidays, err := h.holidayEventRepository.ListEvents(from, until)
+	if err != nil {
+		log.Printf("Error while getting holiday events: %v", err)
+		return nil, fmt.Errorf("Error while getting holiday events: %v", err)
+	}
+	onCallEvents, err := h.onCallEventRepository.ListEvents(from, until)
+	if err != nil {
+		log.Printf("Error while getting on-call events: %v", err)
+		return nil, fmt.Errorf("Error while getting on-call events: %v", err)
+	}
+	return daysOffCoverage(from, until, holidays, onCallEvents), nil
+}
+
+// daysOffCoverage lists who is on call on every weekend day and holiday in [from, until).
+func daysOffCoverage(from, until time.Time, holidays, onCallEvents []Event) []string {
+	var lines []string
+	for day := from; day.Before(until); day = day.AddDate(0, 0, 1) {
+		names := eventSummariesBetween(holidays, day, day.AddDate(0, 0, 1))
+		if len(names) == 0 && !isWeekend(day) {
+			continue
+		}
+
+		label := day.Format(time.DateOnly) + " " + weekdayTh(day.Weekday())
+		if len(names) > 0 {
+			label += " (" + strings.Join(names, ", ") + ")"
+		}
+		dailyStart, dailyEnd := dailyWindow(day)
+		assignees := strings.Join(eventSummariesBetween(onCallEvents, dailyStart, dailyEnd), ", ")
+		if assignees == "" {
+			assignees = "ยังไม่มีคน On-Call"
+		}
+		lines = append(lines, "- "+label+": "+assignees)
+	}
+	return lines
+}
+
+// eventSummariesBetween returns the distinct summaries of the events overlapping [start, end).
+func eventSummariesBetween(events []Event, start, end time.Time) []string {
+	var summaries []string
+	seen := map[string]bool{}
+	for _, event := range events {
+		if event.Start.Before(end) && event.End.After(start) && !seen[event.Summary] {
+			seen[event.Summary] = true
+			summaries = append(summaries, event.Summary)
+		}
+	}
+	return summaries
+}
+
+// formatShift shows a shift's start and end; all-day shifts show their first and last date.
+func formatShift(event Event) string {
+	if event.AllDay {
+		return event.Start.Format(time.DateOnly) + " - " + event.End.AddDate(0, 0, -1).Format(time.DateOnly)
+	}
+	const layout = "2006-01-02 15:04"
+	return event.Start.Format(layout) + " - " + event.End.Format(layout)
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newHandoverTestRepositories(bangkok *time.Location) (*MockEventRepository, *MockEventRepository) {
+	onCallRepo := &MockEventRepository{listed: []Event{
+		{Summary: "Alice", Start: time.Date(2025, 8, 4, 9, 0, 0, 0, bangkok), End: time.Date(2025, 8, 11, 9, 0, 0, 0, bangkok)},
+		{Summary: "Bob", Start: time.Date(2025, 8, 11, 9, 0, 0, 0, bangkok), End: time.Date(2025, 8, 18, 9, 0, 0, 0, bangkok)},
+		{Summary: "Carol", Start: time.Date(2025, 8, 18, 9, 0, 0, 0, bangkok), End: time.Date(2025, 8, 25, 9, 0, 0, 0, bangkok)},
+	}}
+	holidayRepo := &MockEventRepository{listed: []Event{
+		{Summary: "วันแม่แห่งชาติ", Start: time.Date(2025, 8, 12, 0, 0, 0, 0, bangkok), End: time.Date(2025, 8, 13, 0, 0, 0, 0, bangkok), AllDay: true},
+	}}
+	return onCallRepo, holidayRepo
+}
+
+func TestOnCallHandoverService_Notify_AnnouncesHandover(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	onCallRepo, holidayRepo := newHandoverTestRepositories(bangkok)
+	mockNotification := &MockNotificationRepository{}
+	service := NewOnCallHandoverService(onCallRepo, holidayRepo, mockNotification)
+
+	// Act
+	err := service.Notify(time.Date(2025, 8, 11, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if mockNotification.numberOfCalls != 1 {
+		t.Fatalf("Expected notification to be called once, got %d", mockNotification.numberOfCalls)
+	}
+	expectedMessage := "🔄 ส่งต่อเวร On-Call : (2025-08-11)\n" +
+		"- ส่งเวร: Alice (2025-08-04 09:00 - 2025-08-11 09:00)\n" +
+		"- รับเวร: Bob (2025-08-11 09:00 - 2025-08-18 09:00)\n\n" +
+		"📞 ใคร On-Call วันหยุดถัดไป\n" +
+		"- 2025-08-12 อังคาร (วันแม่แห่งชาติ): Bob\n" +
+		"- 2025-08-16 เสาร์: Bob\n" +
+		"- 2025-08-17 อาทิตย์: Bob"
+	if mockNotification.sentMessage != expectedMessage {
+		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
+	}
+}
+
+func TestOnCallHandoverService_Notify_NoHandoverMidShift(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	onCallRepo, holidayRepo := newHandoverTestRepositories(bangkok)
+	mockNotification := &MockNotificationRepository{}
+	service := NewOnCallHandoverService(onCallRepo, holidayRepo, mockNotification)
+
+	// Act
+	err := service.Notify(time.Date(2025, 8, 13, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if mockNotification.numberOfCalls != 0 {
+		t.Errorf("Expected no notification, got %d", mockNotification.numberOfCalls)
+	}
+}
+
+func TestOnCallHandoverService_Notify_SameAssigneeExtendsShift(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	onCallRepo := &MockEventRepository{listed: []Event{
+		{Summary: "Alice", Start: time.Date(2025, 8, 4, 9, 0, 0, 0, bangkok), End: time.Date(2025, 8, 11, 9, 0, 0, 0, bangkok)},
+		{Summary: "Alice", Start: time.Date(2025, 8, 11, 9, 0, 0, 0, bangkok), End: time.Date(2025, 8, 18, 9, 0, 0, 0, bangkok)},
+	}}
+	mockNotification := &MockNotificationRepository{}
+	service := NewOnCallHandoverService(onCallRepo, &MockEventRepository{}, mockNotification)
+
+	// Act
+	err := service.Notify(time.Date(2025, 8, 11, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if mockNotification.numberOfCalls != 0 {
+		t.Errorf("Expected no notification, got %d", mockNotification.numberOfCalls)
+	}
+}
+
+func TestOnCallHandoverService_Notify_GetEventsError(t *testing.T) {
+	// Arrange
+	mockNotification := &MockNotificationRepository{}
+	service := NewOnCallHandoverService(&MockEventRepository{err: errors.New("calendar unavailable")}, &MockEventRepository{}, mockNotification)
+
+	// Act
+	err := service.Notify(time.Date(2025, 8, 11, 8, 0, 0, 0, time.UTC))
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+	if mockNotification.numberOfCalls != 0 {
+		t.Errorf("Expected no notification, got %d", mockNotification.numberOfCalls)
+	}
+}
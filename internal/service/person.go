@@ -0,0 +1,29 @@
+package service
+
+import "time"
+
+// Person is a member of the people directory.
+type Person struct {
+	Name string
+	Team string
+	// WorkingDays are the weekdays a part-time person works; empty means Monday to Friday.
+	WorkingDays []time.Weekday
+}
+
+type PeopleRepository interface {
+	GetPeople() ([]Person, error)
+	// FindPerson returns the person a calendar event such as "Alice (afternoon)" is about.
+	FindPerson(eventSummary string) (Person, bool)
+}
+
+func (p Person) worksOn(day time.Time) bool {
+	if len(p.WorkingDays) == 0 {
+		return !isWeekend(day)
+	}
+	for _, weekday := range p.WorkingDays {
+		if day.Weekday() == weekday {
+			return true
+		}
+	}
+	return false
+}
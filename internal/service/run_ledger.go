@@ -0,0 +1,94 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RunKey identifies a notification sent by a run: the same message of the same job on the same day
+// to the same channel has the same key, so a retried or rerun invocation can recognize it.
+type RunKey struct {
+	Team        string
+	Job         string
+	Date        string
+	Channel     string
+	ContentHash string
+}
+
+// String joins the fields with "/". Each field is path escaped, so a team or channel name that
+// contains "/" cannot make two keys share a string; plain names are left as they are.
+func (k RunKey) String() string {
+	fields := []string{k.Team, k.Job, k.Date, k.Channel, k.ContentHash}
+	for i, field := range fields {
+		fields[i] = url.PathEscape(field)
+	}
+	return strings.Join(fields, "/")
+}
+
+// RunLedger records the notifications already delivered.
+type RunLedger interface {
+	Delivered(key RunKey) (bool, error)
+	RecordDelivered(key RunKey) error
+}
+
+// RunScope is the part of the run key shared by every notification of a run.
+type RunScope struct {
+	Team string
+	Job  string
+	Date time.Time
+}
+
+// LedgerNotificationRepository skips notifications the ledger has already recorded as delivered,
+// so a Lambda retry or a manual rerun does not post the same message twice.
+type LedgerNotificationRepository struct {
+	next    NotificationRepository
+	ledger  RunLedger
+	scope   RunScope
+	channel string
+	// force sends even when the message was delivered before.
+	force bool
+}
+
+func NewLedgerNotificationRepository(next NotificationRepository, ledger RunLedger, scope RunScope,
+	channel string, force bool) LedgerNotificationRepository {
+	return LedgerNotificationRepository{next: next, ledger: ledger, scope: scope, channel: channel, force: force}
+}
+
+// SendNotification records the message once it is delivered. Ledger errors are logged rather
+// than returned: a roster posted twice is better than none, and failing after a delivered send
+// would make Lambda retry it.
+func (l LedgerNotificationRepository) SendNotification(message string) error {
+	key := l.key(message)
+	if !l.force {
+		delivered, err := l.ledger.Delivered(key)
+		if err != nil {
+			log.Printf("Failed to read run ledger, sending anyway: %v", err)
+		} else if delivered {
+			log.Printf("Skipping notification already delivered: %s", key)
+			return nil
+		}
+	}
+
+	if err := l.next.SendNotification(message); err != nil {
+		return err
+	}
+	if err := l.ledger.RecordDelivered(key); err != nil {
+		log.Printf("Failed to record delivery of %s in run ledger: %v", key, err)
+	}
+	return nil
+}
+
+func (l LedgerNotificationRepository) key(message string) RunKey {
+	sum := sha256.Sum256([]byte(message))
+	return RunKey{
+		Team:        l.scope.Team,
+		Job:         l.scope.Job,
+		Date:        l.scope.Date.Format(time.DateOnly),
+		Channel:     l.channel,
+		ContentHash: hex.EncodeToString(sum[:]),
+	}
+}
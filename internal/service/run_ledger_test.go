@@ -0,0 +1,150 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type MockRunLedger struct {
+	delivered map[RunKey]bool
+	err       error
+}
+
+func (m *MockRunLedger) Delivered(key RunKey) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	return m.delivered[key], nil
+}
+
+func (m *MockRunLedger) RecordDelivered(key RunKey) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.delivered == nil {
+		m.delivered = map[RunKey]bool{}
+	}
+	m.delivered[key] = true
+	return nil
+}
+
+var testRunScope = RunScope{Team: "backend", Job: "daily", Date: time.Date(2025, 8, 12, 8, 0, 0, 0, time.UTC)}
+
+func TestLedgerNotificationRepository_SendNotification_SkipsDeliveredMessage(t *testing.T) {
+	// Arrange
+	mockNotification := &MockNotificationRepository{}
+	ledger := &MockRunLedger{}
+	repo := NewLedgerNotificationRepository(mockNotification, ledger, testRunScope, "line", false)
+
+	// Act
+	firstErr := repo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- Alice")
+	retryErr := repo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- Alice")
+	changedErr := repo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- Alice\n- Bob")
+
+	// Assert
+	if firstErr != nil || retryErr != nil || changedErr != nil {
+		t.Fatalf("Expected no errors, got %v, %v and %v", firstErr, retryErr, changedErr)
+	}
+	// The retry is skipped; a changed roster is a different message and is sent.
+	if mockNotification.numberOfCalls != 2 {
+		t.Errorf("Expected notification to be called twice, got %d", mockNotification.numberOfCalls)
+	}
+	if len(ledger.delivered) != 2 {
+		t.Errorf("Expected two deliveries recorded, got %v", ledger.delivered)
+	}
+}
+
+func TestLedgerNotificationRepository_SendNotification_KeyedByChannel(t *testing.T) {
+	// Arrange
+	ledger := &MockRunLedger{}
+	line := &MockNotificationRepository{}
+	email := &MockNotificationRepository{}
+	lineRepo := NewLedgerNotificationRepository(line, ledger, testRunScope, "line", false)
+	emailRepo := NewLedgerNotificationRepository(email, ledger, testRunScope, "email", false)
+
+	// Act
+	lineErr := lineRepo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- Alice")
+	emailErr := emailRepo.SendNotification("📅 วันนี้ใครลา : (2025-08-12)\n- Alice")
+
+	// Assert
+	if lineErr != nil || emailErr != nil {
+		t.Fatalf("Expected no errors, got %v and %v", lineErr, emailErr)
+	}
+	if line.numberOfCalls != 1 || email.numberOfCalls != 1 {
+		t.Errorf("Expected each channel to be notified once, got %d and %d", line.numberOfCalls, email.numberOfCalls)
+	}
+}
+
+func TestLedgerNotificationRepository_SendNotification_Force(t *testing.T) {
+	// Arrange
+	mockNotification := &MockNotificationRepository{}
+	ledger := &MockRunLedger{}
+	NewLedgerNotificationRepository(&MockNotificationRepository{}, ledger, testRunScope, "line", false).SendNotification("hello")
+	repo := NewLedgerNotificationRepository(mockNotification, ledger, testRunScope, "line", true)
+
+	// Act
+	err := repo.SendNotification("hello")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if mockNotification.numberOfCalls != 1 {
+		t.Errorf("Expected notification to be called once, got %d", mockNotification.numberOfCalls)
+	}
+}
+
+func TestLedgerNotificationRepository_SendNotification_FailedSendIsNotRecorded(t *testing.T) {
+	// Arrange
+	mockNotification := &MockNotificationRepository{err: errors.New("line unavailable")}
+	ledger := &MockRunLedger{}
+	repo := NewLedgerNotificationRepository(mockNotification, ledger, testRunScope, "line", false)
+
+	// Act
+	err := repo.SendNotification("hello")
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+	if len(ledger.delivered) != 0 {
+		t.Errorf("Expected nothing recorded, got %v", ledger.delivered)
+	}
+}
+
+func TestLedgerNotificationRepository_SendNotification_SendsWhenLedgerFails(t *testing.T) {
+	// Arrange
+	mockNotification := &MockNotificationRepository{}
+	ledger := &MockRunLedger{err: errors.New("table not found")}
+	repo := NewLedgerNotificationRepository(mockNotification, ledger, testRunScope, "line", false)
+
+	// Act
+	err := repo.SendNotification("hello")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if mockNotification.numberOfCalls != 1 {
+		t.Errorf("Expected notification to be called once, got %d", mockNotification.numberOfCalls)
+	}
+}
+
+func TestRunKey_String_EscapesSeparator(t *testing.T) {
+	// Arrange
+	first := RunKey{Team: "backend/daily", Job: "x", Date: "2025-08-12", Channel: "line", ContentHash: "abc"}
+	second := RunKey{Team: "backend", Job: "daily/x", Date: "2025-08-12", Channel: "line", ContentHash: "abc"}
+	plain := RunKey{Team: "backend", Job: "daily", Date: "2025-08-12", Channel: "line", ContentHash: "abc"}
+
+	// Act
+	firstString, secondString, plainString := first.String(), second.String(), plain.String()
+
+	// Assert
+	if firstString == secondString {
+		t.Errorf("Expected different keys, both are %q", firstString)
+	}
+	if plainString != "backend/daily/2025-08-12/line/abc" {
+		t.Errorf("Expected plain key unchanged, got %q", plainString)
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// WeeklyLeaveDigestService posts who is out during the working week, one line per day, on the
+// first working day of the week.
+type WeeklyLeaveDigestService struct {
+	leaveEventRepository   EventRepository
+	holidayEventRepository EventRepository
+	notificationRepository NotificationRepository
+}
+
+func NewWeeklyLeaveDigestService(leaveEventRepo, holidayEventRepo EventRepository,
+	notificationRepo NotificationRepository) WeeklyLeaveDigestService {
+	return WeeklyLeaveDigestService{
+		leaveEventRepository:   leaveEventRepo,
+		holidayEventRepository: holidayEventRepo,
+		notificationRepository: notificationRepo,
+	}
+}
+
+// Notify sends the digest of asOf's week when asOf is the week's first working day, so the job can
+// be scheduled every weekday and still posts once, on Tuesday when Monday is a holiday.
+func (w WeeklyLeaveDigestService) Notify(asOf time.Time) error {
+	monday := time.Date(asOf.Year(), asOf.Month(), asOf.Day()-(int(asOf.Weekday())+6)%7, 0, 0, 0, 0, asOf.Location())
+	saturday := monday.AddDate(0, 0, 5)
+
+	holidays, err := w.holidayEventRepository.ListEvents(monday, saturday)
+	if err != nil {
+		log.Printf("Error while getting holiday events: %v", err)
+		return fmt.Errorf("Error while getting holiday events: %v", err)
+	}
+	var workingDays []time.Time
+	for day := monday; day.Before(saturday); day = day.AddDate(0, 0, 1) {
+		if len(eventSummariesBetween(holidays, day, day.AddDate(0, 0, 1))) == 0 {
+			workingDays = append(workingDays, day)
+		}
+	}
+	if len(workingDays) == 0 || !sameDate(workingDays[0], asOf) {
+		log.Println("Today " + asOf.Format(time.DateOnly) + " is not the first working day of the week.")
+		return nil
+	}
+
+	leaveEvents, err := w.leaveEventRepository.ListEvents(monday, saturday)
+	if err != nil {
+		log.Printf("Error while getting leave events: %v", err)
+		return fmt.Errorf("Error while getting leave events: %v", err)
+	}
+
+	message := fmt.Sprintf("🗓️ ใครลาสัปดาห์นี้ : (%s - %s)\n", monday.Format(time.DateOnly), saturday.AddDate(0, 0, -1).Format(time.DateOnly))
+	var lines []string
+	daysAbsent := map[string]int{}
+	var absentees []string
+	for day := monday; day.Before(saturday); day = day.AddDate(0, 0, 1) {
+		label := "- " + day.Format(time.DateOnly) + " " + weekdayTh(day.Weekday()) + ": "
+		if names := eventSummariesBetween(holidays, day, day.AddDate(0, 0, 1)); len(names) > 0 {
+			lines = append(lines, label+"🎉 "+strings.Join(names, ", "))
+			continue
+		}
+		start, end := dailyWindow(day)
+		names := eventSummariesBetween(leaveEvents, start, end)
+		for _, name := range names {
+			if daysAbsent[name] == 0 {
+				absentees = append(absentees, name)
+			}
+			daysAbsent[name]++
+		}
+		if len(names) == 0 {
+			lines = append(lines, label+"-")
+			continue
+		}
+		lines = append(lines, label+strings.Join(names, ", "))
+	}
+	message += strings.Join(lines, "\n")
+
+	var wholeWeek []string
+	for _, name := range absentees {
+		if daysAbsent[name] == len(workingDays) {
+			wholeWeek = append(wholeWeek, "- "+name)
+		}
+	}
+	if len(wholeWeek) > 0 {
+		message += "\n\n⚠️ ลาทั้งสัปดาห์\n" + strings.Join(wholeWeek, "\n")
+	}
+	if len(absentees) == 0 {
+		message = fmt.Sprintf("🗓️ สัปดาห์นี้ไม่มีใครลา 💪 : (%s - %s)", monday.Format(time.DateOnly), saturday.AddDate(0, 0, -1).Format(time.DateOnly))
+	}
+
+	log.Printf("There are %d people on leave this week.", len(absentees))
+	err = w.notificationRepository.SendNotification(message)
+	if err != nil {
+		log.Printf("Failed to send notification: %v", err)
+		return fmt.Errorf("Error while sending notification: %v", err)
+	}
+	return nil
+}
+
+func sameDate(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func allDay(summary string, first time.Time, days int) Event {
+	return Event{Summary: summary, Start: first, End: first.AddDate(0, 0, days), AllDay: true}
+}
+
+func TestWeeklyLeaveDigestService_Notify_SendsGridOnFirstWorkingDay(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	monday := time.Date(2025, 8, 11, 0, 0, 0, 0, bangkok)
+	leaveRepo := &MockEventRepository{listed: []Event{
+		allDay("Alice", monday, 5),
+		allDay("Bob", monday.AddDate(0, 0, 2), 1),
+		{Summary: "Carol (afternoon)", Start: time.Date(2025, 8, 15, 13, 0, 0, 0, bangkok), End: time.Date(2025, 8, 15, 18, 0, 0, 0, bangkok)},
+	}}
+	holidayRepo := &MockEventRepository{listed: []Event{allDay("วันแม่แห่งชาติ", monday.AddDate(0, 0, 1), 1)}}
+	mockNotification := &MockNotificationRepository{}
+	service := NewWeeklyLeaveDigestService(leaveRepo, holidayRepo, mockNotification)
+
+	// Act
+	err := service.Notify(time.Date(2025, 8, 11, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if mockNotification.numberOfCalls != 1 {
+		t.Fatalf("Expected notification to be called once, got %d", mockNotification.numberOfCalls)
+	}
+	// Alice is out on every working day; the holiday does not count against the week.
+	expectedMessage := "🗓️ ใครลาสัปดาห์นี้ : (2025-08-11 - 2025-08-15)\n" +
+		"- 2025-08-11 จันทร์: Alice\n" +
+		"- 2025-08-12 อังคาร: 🎉 วันแม่แห่งชาติ\n" +
+		"- 2025-08-13 พุธ: Alice, Bob\n" +
+		"- 2025-08-14 พฤหัสบดี: Alice\n" +
+		"- 2025-08-15 ศุกร์: Alice, Carol (afternoon)\n\n" +
+		"⚠️ ลาทั้งสัปดาห์\n" +
+		"- Alice"
+	if mockNotification.sentMessage != expectedMessage {
+		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
+	}
+}
+
+func TestWeeklyLeaveDigestService_Notify_WaitsForFirstWorkingDay(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	monday := time.Date(2025, 7, 28, 0, 0, 0, 0, bangkok)
+	holidayRepo := &MockEventRepository{listed: []Event{allDay("วันเฉลิมพระชนมพรรษา", monday, 1)}}
+	leaveRepo := &MockEventRepository{listed: []Event{allDay("Alice", monday.AddDate(0, 0, 1), 1)}}
+	mockNotification := &MockNotificationRepository{}
+	service := NewWeeklyLeaveDigestService(leaveRepo, holidayRepo, mockNotification)
+
+	// Act
+	mondayErr := service.Notify(monday.Add(8 * time.Hour))
+	mondayCalls := mockNotification.numberOfCalls
+	tuesdayErr := service.Notify(monday.AddDate(0, 0, 1).Add(8 * time.Hour))
+	wednesdayErr := service.Notify(monday.AddDate(0, 0, 2).Add(8 * time.Hour))
+
+	// Assert
+	if mondayErr != nil || tuesdayErr != nil || wednesdayErr != nil {
+		t.Fatalf("Expected no errors, got %v, %v, %v", mondayErr, tuesdayErr, wednesdayErr)
+	}
+	if mondayCalls != 0 || mockNotification.numberOfCalls != 1 {
+		t.Errorf("Expected a single notification on Tuesday, got %d on Monday and %d in total", mondayCalls, mockNotification.numberOfCalls)
+	}
+}
+
+func TestWeeklyLeaveDigestService_Notify_NoLeave(t *testing.T) {
+	// Arrange
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	mockNotification := &MockNotificationRepository{}
+	service := NewWeeklyLeaveDigestService(&MockEventRepository{}, &MockEventRepository{}, mockNotification)
+
+	// Act
+	err := service.Notify(time.Date(2025, 8, 18, 8, 0, 0, 0, bangkok))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedMessage := "🗓️ สัปดาห์นี้ไม่มีใครลา 💪 : (2025-08-18 - 2025-08-22)"
+	if mockNotification.sentMessage != expectedMessage {
+		t.Errorf("Expected message '%s', got '%s'", expectedMessage, mockNotification.sentMessage)
+	}
+}
+
+func TestWeeklyLeaveDigestService_Notify_GetEventsError(t *testing.T) {
+	// Arrange
+	mockNotification := &MockNotificationRepository{}
+	service := NewWeeklyLeaveDigestService(&MockEventRepository{err: errors.New("calendar unavailable")}, &MockEventRepository{}, mockNotification)
+
+	// Act
+	err := service.Notify(time.Date(2025, 8, 18, 8, 0, 0, 0, time.UTC))
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+	if mockNotification.numberOfCalls != 0 {
+		t.Errorf("Expected no notification, got %d", mockNotification.numberOfCalls)
+	}
+}
@@ -0,0 +1,18 @@
+package webhook
+
+// Payload is the JSON body iris posts to generic webhook endpoints.
+type Payload struct {
+	// Team is the configured team the roster belongs to.
+	Team string `json:"team"`
+	// Date is the date of the roster the notification is about, formatted as YYYY-MM-DD. It stays
+	// the same when a delivery is retried on a later day.
+	Date     string    `json:"date"`
+	Sections []Section `json:"sections"`
+	// Text is the message as rendered for chat channels, for receivers that only display text.
+	Text string `json:"text"`
+}
+
+type Section struct {
+	Title string   `json:"title"`
+	Items []string `json:"items"`
+}
@@ -0,0 +1,102 @@
+// Package webhook signs and verifies the JSON notifications iris posts to generic webhook endpoints.
+//
+// Each request carries a Unix timestamp header and an HMAC-SHA256 signature header computed over
+// "<timestamp>.<body>" with a shared secret. Receivers should verify both before trusting a payload:
+//
+//	func handle(w http.ResponseWriter, r *http.Request) {
+//		body, err := webhook.VerifyRequest(r, secret, 5*time.Minute)
+//		if err != nil {
+//			http.Error(w, err.Error(), http.StatusUnauthorized)
+//			return
+//		}
+//		var payload webhook.Payload
+//		if err := json.Unmarshal(body, &payload); err != nil {
+//			http.Error(w, err.Error(), http.StatusBadRequest)
+//			return
+//		}
+//		...
+//	}
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	TimestampHeader = "X-Iris-Timestamp"
+	SignatureHeader = "X-Iris-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+var (
+	ErrMissingSignature = errors.New("webhook: missing signature or timestamp header")
+	ErrInvalidSignature = errors.New("webhook: signature does not match payload")
+	ErrExpiredTimestamp = errors.New("webhook: timestamp outside tolerance")
+)
+
+// Sign returns the signature header value for body sent at timestamp.
+func Sign(secret []byte, timestamp time.Time, body []byte) string {
+	return signaturePrefix + hex.EncodeToString(mac(secret, strconv.FormatInt(timestamp.Unix(), 10), body))
+}
+
+// Verify checks the timestamp and signature header values against body. A zero tolerance
+// disables the replay check.
+func Verify(secret []byte, timestamp, signature string, body []byte, tolerance time.Duration, now time.Time) error {
+	if timestamp == "" || signature == "" {
+		return ErrMissingSignature
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("webhook: invalid timestamp %q: %v", timestamp, err)
+	}
+	if tolerance > 0 {
+		age := now.Sub(time.Unix(unix, 0))
+		if age > tolerance || age < -tolerance {
+			return ErrExpiredTimestamp
+		}
+	}
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil || !strings.HasPrefix(signature, signaturePrefix) {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal(got, mac(secret, timestamp, body)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyRequest reads and verifies the body of r, returning it on success. The request body is
+// replaced so it can be read again by the caller.
+func VerifyRequest(r *http.Request, secret []byte, tolerance time.Duration) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("webhook: failed to read body: %v", err)
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = Verify(secret, r.Header.Get(TimestampHeader), r.Header.Get(SignatureHeader), body, tolerance, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+func mac(secret []byte, timestamp string, body []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(timestamp))
+	h.Write([]byte("."))
+	h.Write(body)
+	return h.Sum(nil)
+}
@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestVerify_ValidSignature(t *testing.T) {
+	// Arrange
+	secret := []byte("s3cret")
+	sentAt := time.Date(2025, 8, 12, 1, 0, 0, 0, time.UTC)
+	body := []byte(`{"team":"backend"}`)
+	signature := Sign(secret, sentAt, body)
+
+	// Act
+	err := Verify(secret, strconv.FormatInt(sentAt.Unix(), 10), signature, body, 5*time.Minute, sentAt.Add(time.Minute))
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestVerify_TamperedBody(t *testing.T) {
+	// Arrange
+	secret := []byte("s3cret")
+	sentAt := time.Date(2025, 8, 12, 1, 0, 0, 0, time.UTC)
+	signature := Sign(secret, sentAt, []byte(`{"team":"backend"}`))
+
+	// Act
+	err := Verify(secret, strconv.FormatInt(sentAt.Unix(), 10), signature, []byte(`{"team":"frontend"}`), 5*time.Minute, sentAt)
+
+	// Assert
+	if !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestVerify_WrongSecret(t *testing.T) {
+	// Arrange
+	sentAt := time.Date(2025, 8, 12, 1, 0, 0, 0, time.UTC)
+	body := []byte(`{"team":"backend"}`)
+	signature := Sign([]byte("s3cret"), sentAt, body)
+
+	// Act
+	err := Verify([]byte("other"), strconv.FormatInt(sentAt.Unix(), 10), signature, body, 5*time.Minute, sentAt)
+
+	// Assert
+	if !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestVerify_ExpiredTimestamp(t *testing.T) {
+	// Arrange
+	secret := []byte("s3cret")
+	sentAt := time.Date(2025, 8, 12, 1, 0, 0, 0, time.UTC)
+	body := []byte(`{"team":"backend"}`)
+	signature := Sign(secret, sentAt, body)
+
+	// Act
+	err := Verify(secret, strconv.FormatInt(sentAt.Unix(), 10), signature, body, 5*time.Minute, sentAt.Add(10*time.Minute))
+
+	// Assert
+	if !errors.Is(err, ErrExpiredTimestamp) {
+		t.Errorf("Expected ErrExpiredTimestamp, got %v", err)
+	}
+}
+
+func TestVerify_MissingHeaders(t *testing.T) {
+	// Act
+	err := Verify([]byte("s3cret"), "", "", []byte(`{}`), 5*time.Minute, time.Now())
+
+	// Assert
+	if !errors.Is(err, ErrMissingSignature) {
+		t.Errorf("Expected ErrMissingSignature, got %v", err)
+	}
+}